@@ -0,0 +1,137 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bus provides the Bus type, which publishes the changes of Values
+// registered under names, to consumers subscribed by name or pattern.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+var (
+	// ErrDuplicateName is returned when registering a Value under a name that
+	// is already registered.
+	ErrDuplicateName = errors.New("name already registered")
+)
+
+// Event is a change of a Value registered with a Bus, published to the topic of
+// the name it was registered under.
+type Event struct {
+	Topic string
+	value.Event[any]
+}
+
+// Bus publishes the changes of Values registered under names. Its zero value is
+// ready to use.
+type Bus struct {
+	mu         sync.Mutex
+	registered map[string]func()
+	// events is set to each published Event, which Subscribe subscribes to.
+	events value.Value[Event]
+}
+
+// New returns a new Bus.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Register registers v with b under name, and returns a function that
+// unregisters it. Each subsequent change of v is published to the topic name.
+// ErrDuplicateName is returned if another Value is registered under name.
+func Register[T any](b *Bus, name string, v *value.Value[T]) (unregister func(), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.registered[name]; ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrDuplicateName)
+	}
+
+	if b.registered == nil {
+		b.registered = map[string]func(){}
+	}
+
+	cancel := v.OnEvent(func(e value.Event[T]) {
+		b.events.Set(Event{Topic: name, Event: e.Any()})
+	})
+	b.registered[name] = cancel
+
+	var once sync.Once
+	unregister = func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			cancel()
+			delete(b.registered, name)
+		})
+	}
+
+	return unregister, nil
+}
+
+// Names returns the names Values are registered under, in sorted order.
+func (b *Bus) Names() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	names := make([]string, 0, len(b.registered))
+	for name := range b.registered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Subscribe returns a channel on which every subsequently published Event
+// whose topic matches pattern is delivered, in order, until ctx is done, after
+// which the channel is closed. pattern has the syntax of path.Match, so a name
+// without special characters matches only that topic, and a pattern such as
+// "db/*" matches the topics of every name in "db". Events are queued for the
+// subscriber, so publishing never blocks on a slow receiver. If pattern is
+// malformed, path.ErrBadPattern is returned.
+func (b *Bus) Subscribe(ctx context.Context, pattern string) (<-chan Event, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	events := b.events.Subscribe(ctx)
+	matched := make(chan Event)
+
+	go func() {
+		defer close(matched)
+
+		for e := range events {
+			if ok, _ := path.Match(pattern, e.Topic); !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case matched <- e:
+			}
+		}
+	}()
+
+	return matched, nil
+}
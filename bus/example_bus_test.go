@@ -0,0 +1,64 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bus_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.incompletion.ist/explicit/bus"
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleBus_Subscribe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dbHost, dbPort, logLevel value.Value[string]
+
+	b := bus.New()
+	bus.Register(b, "db/host", &dbHost)
+	bus.Register(b, "db/port", &dbPort)
+	bus.Register(b, "log/level", &logLevel)
+
+	db, _ := b.Subscribe(ctx, "db/*")
+
+	dbHost.Set("localhost")
+	logLevel.Set("debug")
+	dbPort.Set("5432")
+
+	for i := 0; i < 2; i++ {
+		e := <-db
+		fmt.Println(e.Topic, e.New)
+	}
+
+	// Output: db/host localhost
+	// db/port 5432
+}
+
+func ExampleBus_Names() {
+	var timeout value.Value[int]
+
+	b := bus.New()
+	unregister, _ := bus.Register(b, "http/timeout", &timeout)
+	_, err := bus.Register(b, "http/timeout", &timeout)
+	fmt.Println(b.Names(), err)
+
+	unregister()
+	fmt.Println(b.Names())
+
+	// Output: [http/timeout] http/timeout: name already registered
+	// []
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonx_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/encoding/jsonx"
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleMarshal() {
+	type limits struct {
+		CPU    value.Value[string] `json:"cpu"`
+		Memory value.Value[string] `json:"memory"`
+	}
+
+	type update struct {
+		Name     string              `json:"name"`
+		Replicas value.Value[int]    `json:"replicas"`
+		Image    value.Value[string] `json:"image"`
+		Limits   *limits             `json:"limits,omitempty"`
+		Labels   []string            `json:"labels,omitempty"`
+	}
+
+	req := update{Name: "api", Limits: &limits{}}
+	req.Replicas.Set(0)
+	req.Limits.Memory.Set("512Mi")
+
+	encoded, err := jsonx.Marshal(&req)
+	fmt.Println(string(encoded), err)
+
+	// Output: {"name":"api","replicas":0,"limits":{"memory":"512Mi"}} <nil>
+}
+
+func ExampleMarshal_embedded() {
+	type Base struct {
+		ID   value.Value[int]    `json:"id"`
+		Name value.Value[string] `json:"name"`
+		Kind value.Value[string]
+	}
+
+	type Meta struct {
+		Kind value.Value[string]
+	}
+
+	type Outer struct {
+		Base
+		Meta
+		Name value.Value[string] `json:"name"`
+	}
+
+	var outer Outer
+	outer.ID.Set(7)
+	outer.Base.Name.Set("base")
+	outer.Name.Set("outer")
+	outer.Base.Kind.Set("base")
+	outer.Meta.Kind.Set("meta")
+
+	// the shallower Name hides the one in Base, and the Kinds at the same
+	// depth are ambiguous, so neither is encoded
+	encoded, err := jsonx.Marshal(&outer)
+	fmt.Println(string(encoded), err)
+
+	// Output: {"id":7,"name":"outer"} <nil>
+}
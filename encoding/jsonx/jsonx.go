@@ -0,0 +1,372 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonx provides JSON encoding that omits unset Values, which
+// encoding/json can't do for struct fields, even with omitempty.
+package jsonx
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// settable is implemented by Values, and the types built on them.
+type settable interface {
+	IsSet() bool
+	json.Marshaler
+}
+
+var (
+	settableType      = reflect.TypeOf((*settable)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Marshal returns the JSON encoding of v, like json.Marshal, but omits the
+// fields of structs that are Values which aren't explicitly set, rather than
+// encoding them as null. Structs are walked recursively, including those
+// pointed to and embedded, whose fields are encoded as though they were fields
+// of the outer struct, following the rules encoding/json uses to resolve
+// conflicting names. Field names, "-", and the omitempty option of json
+// struct tags are respected, but other options are not. Types that implement
+// json.Marshaler or encoding.TextMarshaler, and values that aren't structs, are
+// encoded by json.Marshal.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encode writes the JSON encoding of rv to buf.
+func encode(buf *bytes.Buffer, rv reflect.Value) error {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			buf.WriteString("null")
+
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.Struct {
+		rv = addressable(rv)
+	}
+
+	if rv.Kind() != reflect.Struct || marshals(rv.Type()) {
+		return encodeJSON(buf, rv)
+	}
+
+	return encodeFields(buf, rv)
+}
+
+// encodeFields writes the fields of the addressable struct rv to buf, as an
+// object.
+func encodeFields(buf *bytes.Buffer, rv reflect.Value) error {
+	buf.WriteByte('{')
+	first := true
+	for _, field := range fields(rv.Type()) {
+		fieldValue, ok := fieldByIndex(rv, field.index)
+		if !ok {
+			continue
+		}
+
+		if value, ok := fieldValue.Addr().Interface().(settable); ok && !value.IsSet() {
+			continue
+		}
+
+		if field.omitEmpty && isEmpty(fieldValue) {
+			continue
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		if err := encode(buf, fieldValue.Addr()); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// field is a field that is encoded as a member of an object, which may be
+// promoted from an embedded struct.
+type field struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+}
+
+// fields returns the encoded fields of the struct type rt, including those
+// promoted from embedded structs, in the order they are declared. Conflicting
+// names are resolved like encoding/json: the shallowest field wins, then the
+// one named by its json struct tag, and names that remain ambiguous are
+// omitted.
+func fields(rt reflect.Type) []field {
+	type embedded struct {
+		rt    reflect.Type
+		index []int
+	}
+
+	var found []field
+	visited := map[reflect.Type]bool{}
+	for next := []embedded{{rt: rt}}; len(next) > 0; {
+		current := next
+		next = nil
+
+		// the fields of a type already expanded at a shallower depth are
+		// hidden by those it promoted there
+		expanded := map[reflect.Type]bool{}
+		for _, e := range current {
+			if visited[e.rt] {
+				continue
+			}
+			expanded[e.rt] = true
+
+			for i := 0; i < e.rt.NumField(); i++ {
+				structField := e.rt.Field(i)
+				name, omitEmpty, ok := fieldName(structField)
+				if !ok {
+					continue
+				}
+
+				index := append(append([]int(nil), e.index...), i)
+				if structField.Anonymous && name == "" && embeds(structField.Type) {
+					fieldType := structField.Type
+					if fieldType.Kind() == reflect.Pointer {
+						fieldType = fieldType.Elem()
+					}
+					next = append(next, embedded{rt: fieldType, index: index})
+
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = structField.Name
+				}
+				found = append(found, field{name: name, index: index, tagged: tagged, omitEmpty: omitEmpty})
+			}
+		}
+
+		for rt := range expanded {
+			visited[rt] = true
+		}
+	}
+
+	// fields are found in order of depth, so the first of each name is the
+	// shallowest
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].name < found[j].name
+	})
+
+	var resolved []field
+	for i := 0; i < len(found); {
+		j := i + 1
+		for j < len(found) && found[j].name == found[i].name {
+			j++
+		}
+
+		if dominant, ok := dominantField(found[i:j]); ok {
+			resolved = append(resolved, dominant)
+		}
+		i = j
+	}
+
+	sort.Slice(resolved, func(i, j int) bool {
+		return lessIndex(resolved[i].index, resolved[j].index)
+	})
+
+	return resolved
+}
+
+// dominantField returns the field that is encoded of fields sharing a name,
+// sorted by depth, and false if none is, because the name is ambiguous.
+func dominantField(fields []field) (field, bool) {
+	depth := len(fields[0].index)
+	var dominant []field
+	for _, f := range fields {
+		if len(f.index) > depth {
+			break
+		}
+		dominant = append(dominant, f)
+	}
+
+	if len(dominant) == 1 {
+		return dominant[0], true
+	}
+
+	var tagged []field
+	for _, f := range dominant {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return field{}, false
+}
+
+// lessIndex indicates if the field at index a is declared before the field at
+// index b.
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// fieldByIndex returns the field of the struct rv at index, following pointers
+// to embedded structs. It returns false if the field is promoted through a nil
+// pointer, so isn't encoded.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+
+	return rv, true
+}
+
+// encodeJSON writes the encoding of rv by json.Marshal to buf, using its
+// address if it is addressable, so methods with pointer receivers are used.
+func encodeJSON(buf *bytes.Buffer, rv reflect.Value) error {
+	if !rv.IsValid() {
+		buf.WriteString("null")
+
+		return nil
+	}
+
+	if rv.CanAddr() {
+		rv = rv.Addr()
+	}
+
+	encoded, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+
+	return nil
+}
+
+// fieldName returns the name of field in its json struct tag, which is empty if
+// it isn't named, and a boolean indicating if the tag has the omitempty option.
+// It returns false if field isn't encoded, because it is unexported or its tag
+// is "-".
+func fieldName(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	for options != "" {
+		var option string
+		option, options, _ = strings.Cut(options, ",")
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	if !field.IsExported() && !(field.Anonymous && embeds(field.Type)) {
+		return "", false, false
+	}
+
+	return name, omitEmpty, true
+}
+
+// embeds indicates if the fields of an embedded field of type rt are encoded as
+// though they were fields of the outer struct, because it is a struct, or
+// pointer to one, that isn't encoded by its own methods.
+func embeds(rt reflect.Type) bool {
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+
+	return rt.Kind() == reflect.Struct && !marshals(rt)
+}
+
+// marshals indicates if rt, or a pointer to it, encodes itself, by implementing
+// json.Marshaler or encoding.TextMarshaler.
+func marshals(rt reflect.Type) bool {
+	pt := reflect.PointerTo(rt)
+
+	return pt.Implements(settableType) ||
+		pt.Implements(marshalerType) ||
+		pt.Implements(textMarshalerType)
+}
+
+// addressable returns rv, or an addressable copy of it if it isn't
+// addressable, so methods with pointer receivers can be called on its fields.
+func addressable(rv reflect.Value) reflect.Value {
+	if rv.CanAddr() {
+		return rv
+	}
+
+	copied := reflect.New(rv.Type()).Elem()
+	copied.Set(rv)
+
+	return copied
+}
+
+// isEmpty indicates if rv is empty, as defined by the omitempty option of
+// encoding/json.
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	}
+
+	return false
+}
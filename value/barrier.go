@@ -0,0 +1,148 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"sync"
+)
+
+// Barrier releases waiters once a number of Values have been explicitly set,
+// such as the sources that must be loaded before starting. A Barrier must be
+// created with NewBarrier.
+type Barrier struct {
+	mu       sync.Mutex
+	need     int
+	set      map[int]bool
+	released chan struct{}
+	cancels  []func()
+}
+
+// NewBarrier returns a new Barrier over vs, which is released once need of
+// them have been explicitly set, either before NewBarrier was called, if they
+// are still set, or since. If need is less than 1, or more than the number of
+// vs, all of them must be set. Values that are unset again after being set
+// still count as set.
+func NewBarrier(need int, vs ...Watchable) *Barrier {
+	if need < 1 || need > len(vs) {
+		need = len(vs)
+	}
+
+	b := &Barrier{
+		need:     need,
+		set:      make(map[int]bool, len(vs)),
+		released: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if need == 0 {
+		close(b.released)
+
+		return b
+	}
+
+	for i, v := range vs {
+		if b.isReleasedLocked() {
+			break
+		}
+
+		i := i
+		b.cancels = append(b.cancels, v.watchSet(func() {
+			b.markSet(i)
+		}))
+
+		// checked after watching, so a concurrent set isn't missed
+		if v.IsSet() {
+			b.markSetLocked(i)
+		}
+	}
+
+	return b
+}
+
+// isReleasedLocked returns a boolean indicating if the Barrier has been
+// released. The lock must be held by the caller.
+func (b *Barrier) isReleasedLocked() bool {
+	return len(b.set) >= b.need
+}
+
+// markSet records that the Value at index i has been set.
+func (b *Barrier) markSet(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.markSetLocked(i)
+}
+
+// markSetLocked records that the Value at index i has been set, releasing the
+// Barrier if enough have been. The lock must be held by the caller.
+func (b *Barrier) markSetLocked(i int) {
+	if b.set[i] || b.isReleasedLocked() {
+		return
+	}
+
+	b.set[i] = true
+	if !b.isReleasedLocked() {
+		return
+	}
+
+	close(b.released)
+
+	// the Values are no longer watched, as nothing else can change
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+	b.cancels = nil
+}
+
+// Progress returns the number of Values that have been set, and the number
+// that must be set to release the Barrier.
+func (b *Barrier) Progress() (set, need int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.set), b.need
+}
+
+// Done returns a channel that is closed once the Barrier is released.
+func (b *Barrier) Done() <-chan struct{} {
+	return b.released
+}
+
+// Wait blocks until the Barrier is released, or the Context is cancelled, in
+// which case a WaitError is returned, like GetWait.
+func (b *Barrier) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return waitError(ctx.Err())
+	case <-b.released:
+		return nil
+	}
+}
+
+// Stop stops watching the Values of the Barrier, which will never be released
+// if it hasn't been already.
+func (b *Barrier) Stop() {
+	b.mu.Lock()
+	cancels := b.cancels
+	b.cancels = nil
+	b.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
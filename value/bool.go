@@ -0,0 +1,54 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// Bool is a Value of type bool, with conveniences for flag-style usage.
+type Bool struct {
+	Value[bool]
+}
+
+// SetTrue sets the value explicitly to true.
+func (b *Bool) SetTrue() {
+	b.Set(true)
+}
+
+// SetFalse sets the value explicitly to false.
+func (b *Bool) SetFalse() {
+	b.Set(false)
+}
+
+// Toggle sets the value explicitly to the opposite of the stored value, and
+// returns the new value. An unset Bool toggles to true.
+//
+// Toggle holds the lock while reading and setting the value.
+func (b *Bool) Toggle() bool {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.setLocked(b.mustPrepareLocked(!b.stored))
+
+	return b.stored
+}
+
+// NewBool returns a new Bool with its value explicitly set, configured by
+// opts.
+func NewBool(storeValue bool, opts ...Option[bool]) *Bool {
+	var newBool Bool
+
+	newBool.configure(opts)
+	newBool.Set(storeValue)
+
+	return &newBool
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "fmt"
+
+// BoundPolicy determines how a Bounded handles values outside of its bounds.
+type BoundPolicy int
+
+const (
+	// Reject causes values outside of the bounds to be rejected with
+	// ErrOutOfRange.
+	Reject BoundPolicy = iota
+
+	// Clamp causes values outside of the bounds to be replaced with the
+	// nearest bound.
+	Clamp
+)
+
+// Bounded is a Value of an ordered type, whose stored value is kept within a
+// minimum and maximum. A Bounded must be created with NewBounded.
+type Bounded[T ordered] struct {
+	Value[T]
+	min T
+	max T
+}
+
+// NewBounded returns a new unset Bounded with the inclusive bounds min and max,
+// handling values outside of them according to policy, and configured by opts.
+// The bounds are enforced after any normalizers in opts have been applied.
+func NewBounded[T ordered](min, max T, policy BoundPolicy, opts ...Option[T]) *Bounded[T] {
+	newBounded := Bounded[T]{min: min, max: max}
+
+	switch policy {
+	case Clamp:
+		opts = append(opts, WithNormalizer(newBounded.clamp))
+	default:
+		opts = append(opts, WithValidator(newBounded.check))
+	}
+
+	newBounded.configure(opts)
+
+	return &newBounded
+}
+
+// clamp returns storeValue, or the nearest bound if it is outside of them.
+func (b *Bounded[T]) clamp(storeValue T) T {
+	if storeValue < b.min {
+		return b.min
+	}
+
+	if storeValue > b.max {
+		return b.max
+	}
+
+	return storeValue
+}
+
+// check returns ErrOutOfRange if storeValue is outside of the bounds.
+func (b *Bounded[T]) check(storeValue T) error {
+	if storeValue < b.min || storeValue > b.max {
+		return fmt.Errorf("%v not in [%v, %v]: %w", storeValue, b.min, b.max, ErrOutOfRange)
+	}
+
+	return nil
+}
+
+// Min returns the minimum bound.
+func (b *Bounded[T]) Min() T {
+	return b.min
+}
+
+// Max returns the maximum bound.
+func (b *Bounded[T]) Max() T {
+	return b.max
+}
@@ -0,0 +1,298 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"sync"
+)
+
+// CallbackOption configures a callback registered with a Value, such as by
+// OnSet.
+type CallbackOption[T any] func(*callbackOptions[T])
+
+// callbackOptions holds the configuration of a callback.
+type callbackOptions[T any] struct {
+	async  bool
+	ctx    context.Context
+	replay bool
+}
+
+// Async configures a callback to be called by a goroutine of its own, rather
+// than by the goroutine that changed the Value. Calls are still made one at a
+// time, in the order of the changes, and changing the Value never waits for
+// them.
+func Async[T any]() CallbackOption[T] {
+	return func(o *callbackOptions[T]) {
+		o.async = true
+	}
+}
+
+// WithContext configures a callback to be removed once ctx is cancelled, as if
+// by calling the function returned when registering it. It isn't called for
+// changes made after ctx is cancelled. This permits short-lived consumers of
+// long-lived Values to register callbacks without having to remember to remove
+// them.
+func WithContext[T any](ctx context.Context) CallbackOption[T] {
+	return func(o *callbackOptions[T]) {
+		o.ctx = ctx
+	}
+}
+
+// WithReplayCurrent configures a callback to be called once when it is
+// registered, if the Value is explicitly set, as though the Value was just set
+// to its current value, so it doesn't wait for the next set to learn of it.
+// The call is made like any other, before calls for subsequent changes, and
+// isn't passed through the interceptors configured with WithInterceptor. It
+// applies to the callbacks that are called for explicit sets, such as by OnSet,
+// OnChange, and OnEvent.
+func WithReplayCurrent[T any]() CallbackOption[T] {
+	return func(o *callbackOptions[T]) {
+		o.replay = true
+	}
+}
+
+// OnSet registers fn to be called with each value v is subsequently explicitly
+// set to, and returns a function that removes it. Unless configured with Async,
+// fn is called without holding the lock, one value at a time, in order, by the
+// goroutine that set the value before its call returns, or by a goroutine that
+// is still calling callbacks for an earlier change.
+func (v *Value[T]) OnSet(fn func(T), opts ...CallbackOption[T]) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind == EventSet {
+			fn(e.New)
+		}
+	}, opts)
+}
+
+// OnEvent registers fn to be called with an Event describing each subsequent
+// change of v, whether by setting, unsetting, or expiring, and returns a
+// function that removes it. It is called like the callbacks registered by
+// OnSet. This permits auditing or diffing changes.
+func (v *Value[T]) OnEvent(fn func(Event[T]), opts ...CallbackOption[T]) (cancel func()) {
+	return v.handle(fn, opts)
+}
+
+// OnChange registers fn to be called with the previous and new values each time
+// v is subsequently explicitly set to a different value, and returns a function
+// that removes it. It is called like the callbacks registered by OnSet. Values
+// are only compared if v was configured with WithEqual, so otherwise every set
+// is a change. If v was unset, old is the value it stored while unset.
+func (v *Value[T]) OnChange(fn func(old, new T), opts ...CallbackOption[T]) (cancel func()) {
+	return v.onChange(fn, nil, opts)
+}
+
+// onChange implements OnChange, skipping sets that equal reports as not
+// changing the value, if equal is not nil.
+func (v *Value[T]) onChange(fn func(old, new T), equal func(a, b T) bool, opts []CallbackOption[T]) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind != EventSet {
+			return
+		}
+
+		if equal != nil && e.OldSet && equal(e.Old, e.New) {
+			return
+		}
+
+		fn(e.Old, e.New)
+	}, opts)
+}
+
+// OnUnset registers fn to be called with the previous value each time v is
+// subsequently unset while it was explicitly set, such as by Unset, Reset, or
+// expiring, and returns a function that removes it. It is called like the
+// callbacks registered by OnSet. This permits invalidating state derived from
+// a value when it is revoked, rather than only when it is replaced.
+func (v *Value[T]) OnUnset(fn func(old T), opts ...CallbackOption[T]) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind == EventUnset || e.Kind == EventExpire {
+			fn(e.Old)
+		}
+	}, opts)
+}
+
+// OnExpire registers fn to be called with the previous value each time v is
+// subsequently unset by expiring, and returns a function that removes it. It is
+// called like the callbacks registered by OnSet.
+func (v *Value[T]) OnExpire(fn func(old T), opts ...CallbackOption[T]) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind == EventExpire {
+			fn(e.Old)
+		}
+	}, opts)
+}
+
+// NotifyTo registers ch to be sent a signal each time v is subsequently
+// explicitly set, and returns a function that removes it. Signals are sent
+// without blocking, so if ch has no room because an earlier signal hasn't been
+// received yet, the signal is dropped, coalescing the sets it signals. This
+// permits a Value to participate in an existing select loop, without
+// allocating a channel per wait. A buffer of one is typically sufficient.
+func (v *Value[T]) NotifyTo(ch chan<- struct{}) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind != EventSet {
+			return
+		}
+
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}, nil)
+}
+
+// handle registers h to be called for each change of v, configured by opts,
+// and returns a function that removes it.
+func (v *Value[T]) handle(h func(Event[T]), opts []CallbackOption[T]) (cancel func()) {
+	var o callbackOptions[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if ctx := o.ctx; ctx != nil {
+		// removal is asynchronous, so skip calls once ctx is cancelled
+		next := h
+		h = func(e Event[T]) {
+			if ctx.Err() == nil {
+				next(e)
+			}
+		}
+	}
+
+	stop := func() {}
+	if o.async {
+		h, stop = async(h)
+	}
+
+	v.mu.Lock()
+	id := v.addHandlerLocked(h)
+	if o.replay && v.set {
+		current := v.currentEventLocked()
+		v.notifyQueue = append(v.notifyQueue, func() {
+			h(current)
+		})
+	}
+	v.unlock()
+
+	var cancelOnce sync.Once
+	removed := make(chan struct{})
+	cancel = func() {
+		cancelOnce.Do(func() {
+			v.mu.Lock()
+			v.removeHandlerLocked(id)
+			v.mu.Unlock()
+
+			stop()
+			close(removed)
+		})
+	}
+
+	if o.ctx != nil {
+		go func() {
+			select {
+			case <-o.ctx.Done():
+				cancel()
+			case <-removed:
+			}
+		}()
+	}
+
+	return cancel
+}
+
+// handler is a function registered by handle.
+type handler[T any] struct {
+	id uint64
+	fn func(Event[T])
+}
+
+// addHandlerLocked registers h, and returns the id to remove it with. The lock
+// must be held by the caller.
+func (v *Value[T]) addHandlerLocked(h func(Event[T])) uint64 {
+	v.lastHandler++
+	v.handlers = append(v.handlers, handler[T]{id: v.lastHandler, fn: h})
+
+	return v.lastHandler
+}
+
+// removeHandlerLocked removes the handler registered with id, if it is still
+// registered. The lock must be held by the caller.
+func (v *Value[T]) removeHandlerLocked(id uint64) {
+	for i, h := range v.handlers {
+		if h.id == id {
+			// copied, as emitLocked may have queued the current slice
+			handlers := make([]handler[T], 0, len(v.handlers)-1)
+			handlers = append(handlers, v.handlers[:i]...)
+			v.handlers = append(handlers, v.handlers[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// async returns a function that queues calls to fn, which a separate goroutine
+// makes in order, and a function that stops the goroutine, discarding calls
+// that haven't been made.
+func async[E any](fn func(E)) (queue func(E), stop func()) {
+	var mu sync.Mutex
+	var pending []E
+	ready := make(chan struct{}, 1)
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+
+	go func() {
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ready:
+			}
+
+			mu.Lock()
+			calls := pending
+			pending = nil
+			mu.Unlock()
+
+			for _, call := range calls {
+				select {
+				case <-stopped:
+					return
+				default:
+				}
+
+				fn(call)
+			}
+		}
+	}()
+
+	queue = func(e E) {
+		mu.Lock()
+		pending = append(pending, e)
+		mu.Unlock()
+
+		select {
+		case ready <- struct{}{}:
+		default:
+		}
+	}
+
+	stop = func() {
+		stopOnce.Do(func() {
+			close(stopped)
+		})
+	}
+
+	return queue, stop
+}
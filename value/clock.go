@@ -0,0 +1,149 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, and schedules functions to run in the
+// future. It permits substituting the system clock, such as in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine after d has elapsed, unless the
+	// returned Timer is stopped first.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a function scheduled by a Clock.
+type Timer interface {
+	// Stop prevents the function from being called, and returns a boolean
+	// indicating if it was stopped before being called.
+	Stop() bool
+}
+
+// systemClock is a Clock that uses the system time.
+type systemClock struct{}
+
+// Now returns the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc calls f after d has elapsed, using time.AfterFunc.
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// ManualClock is a Clock whose time only changes when it is advanced. Scheduled
+// functions are called synchronously by Advance, making it suitable for
+// deterministic tests.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+// manualTimer is a function scheduled by a ManualClock.
+type manualTimer struct {
+	clock *ManualClock
+	at    time.Time
+	f     func()
+}
+
+// NewManualClock returns a new ManualClock whose current time is now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the current time of the ManualClock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules f to be called by Advance, once the ManualClock has been
+// advanced by at least d.
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &manualTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+
+	return timer
+}
+
+// Advance moves the current time of the ManualClock forward by d, and calls
+// any functions that became due, in the order of their scheduled times.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		timer := c.popDue()
+		if timer == nil {
+			return
+		}
+
+		timer.f()
+	}
+}
+
+// popDue removes and returns the earliest scheduled timer that is due, or nil
+// if none are.
+//
+// popDue acquires the lock from start to finish.
+func (c *ManualClock) popDue() *manualTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+
+	if len(c.timers) == 0 || c.timers[0].at.After(c.now) {
+		return nil
+	}
+
+	timer := c.timers[0]
+	c.timers = c.timers[1:]
+
+	return timer
+}
+
+// Stop removes the timer from its ManualClock, and returns a boolean indicating
+// if it hadn't been called yet.
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"time"
+)
+
+// Comparable is a Value of a comparable type, which permits operations that
+// compare stored values. Setting a Comparable to the value it already holds is
+// a no-op, which doesn't wake waiters or increment the version, whether it is
+// set by Set, by the methods it has in common with Value, such as Update or
+// Swap, or by a Txn, staged by its TxnSet method.
+type Comparable[T comparable] struct {
+	Value[T]
+}
+
+// equal reports whether a and b are equal.
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+// useEqualLocked configures the Value to skip setting equal values. The lock
+// must be held by the caller.
+func (c *Comparable[T]) useEqualLocked() {
+	c.comparableEqual = equal[T]
+}
+
+// useEqual is like useEqualLocked, but acquires the lock.
+func (c *Comparable[T]) useEqual() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.useEqualLocked()
+}
+
+// value returns the Value of c, configured to skip setting equal values.
+func (c *Comparable[T]) value() *Value[T] {
+	c.useEqual()
+
+	return &c.Value
+}
+
+// Set sets the value explicitly, unless it is already set to an equal value.
+// Set panics if the value can't be set, such as when the Comparable is frozen.
+func (c *Comparable[T]) Set(storeValue T) {
+	if err := c.SetErr(storeValue); err != nil {
+		panic(err)
+	}
+}
+
+// SetErr sets the value explicitly, unless it is already set to an equal value,
+// or returns an error if it can't be set.
+func (c *Comparable[T]) SetErr(storeValue T) error {
+	c.useEqual()
+	_, err := c.setErr(storeValue, "", equal[T])
+
+	return err
+}
+
+// SetChanged is like Set, but returns a boolean indicating if the stored value
+// was changed.
+func (c *Comparable[T]) SetChanged(storeValue T) bool {
+	c.useEqual()
+
+	return c.SetChangedFunc(storeValue, equal[T])
+}
+
+// SetChangedFunc is like the SetChangedFunc method of Value, but later sets
+// also skip equal values.
+func (c *Comparable[T]) SetChangedFunc(storeValue T, equal func(a, b T) bool) bool {
+	return c.value().SetChangedFunc(storeValue, equal)
+}
+
+// SetWithSource is like Set, but also records source as the origin of the
+// value, like the SetWithSource method of Value.
+func (c *Comparable[T]) SetWithSource(storeValue T, source string) {
+	c.value().SetWithSource(storeValue, source)
+}
+
+// SetWithTTL is like Set, but the value reverts to unset after ttl has elapsed,
+// like the SetWithTTL method of Value.
+func (c *Comparable[T]) SetWithTTL(storeValue T, ttl time.Duration) {
+	c.value().SetWithTTL(storeValue, ttl)
+}
+
+// TrySet is like the TrySet method of Value, but skips setting an equal value.
+func (c *Comparable[T]) TrySet(storeValue T) bool {
+	return c.value().TrySet(storeValue)
+}
+
+// SetContext is like the SetContext method of Value, but skips setting an
+// equal value.
+func (c *Comparable[T]) SetContext(ctx context.Context, storeValue T) error {
+	return c.value().SetContext(ctx, storeValue)
+}
+
+// SetIfUnset is like the SetIfUnset method of Value, but later sets skip equal
+// values.
+func (c *Comparable[T]) SetIfUnset(storeValue T) bool {
+	return c.value().SetIfUnset(storeValue)
+}
+
+// SetIf is like the SetIf method of Value, but skips setting an equal value.
+func (c *Comparable[T]) SetIf(storeValue T, pred func(current T, set bool) bool) bool {
+	return c.value().SetIf(storeValue, pred)
+}
+
+// GetOrSet is like the GetOrSet method of Value, but later sets skip equal
+// values.
+func (c *Comparable[T]) GetOrSet(storeValue T) T {
+	return c.value().GetOrSet(storeValue)
+}
+
+// Swap is like the Swap method of Value, but skips setting an equal value.
+func (c *Comparable[T]) Swap(storeValue T) (T, bool) {
+	return c.value().Swap(storeValue)
+}
+
+// Update is like the Update method of Value, but skips setting a value equal to
+// the stored value.
+func (c *Comparable[T]) Update(fn func(T) T) {
+	c.value().Update(fn)
+}
+
+// UpdateErr is like the UpdateErr method of Value, but skips setting a value
+// equal to the stored value.
+func (c *Comparable[T]) UpdateErr(fn func(T) (T, error)) error {
+	return c.value().UpdateErr(fn)
+}
+
+// UnmarshalJSON is like the UnmarshalJSON method of Value, but skips setting a
+// decoded value equal to the stored value.
+func (c *Comparable[T]) UnmarshalJSON(data []byte) error {
+	return c.value().UnmarshalJSON(data)
+}
+
+// Writer returns a Writer handle to c, which skips setting equal values.
+func (c *Comparable[T]) Writer() Writer[T] {
+	return c.value().Writer()
+}
+
+// Clone is like the Clone method of Value, but the returned Value also skips
+// setting equal values.
+func (c *Comparable[T]) Clone() *Value[T] {
+	return c.value().Clone()
+}
+
+// TxnSet stages explicitly setting c to storeValue when txn is committed, like
+// the TxnSet function, unless it is then already set to an equal value.
+func (c *Comparable[T]) TxnSet(txn *Txn, storeValue T) {
+	TxnSet(txn, c.value(), storeValue)
+}
+
+// Equal reports whether c and other are both unset, or both explicitly set to
+// equal values.
+func (c *Comparable[T]) Equal(other *Comparable[T]) bool {
+	return c.equalFunc(&other.Value, equal[T])
+}
+
+// OnChange is like the OnChange method of Value, but fn is only called when the
+// new value is not equal to the previous value.
+func (c *Comparable[T]) OnChange(fn func(old, new T), opts ...CallbackOption[T]) (cancel func()) {
+	return c.onChange(fn, equal[T], opts)
+}
+
+// CompareAndSwap sets the value explicitly to newValue if it is already
+// explicitly set to oldValue, and returns a boolean indicating if the swap
+// happened.
+//
+// CompareAndSwap holds the lock while comparing and setting the value.
+func (c *Comparable[T]) CompareAndSwap(oldValue, newValue T) bool {
+	c.mu.Lock()
+	defer c.unlock()
+
+	c.useEqualLocked()
+
+	if !c.set || c.stored != oldValue {
+		return false
+	}
+
+	c.setLocked(c.mustPrepareLocked(newValue))
+
+	return true
+}
+
+// WaitEqual blocks until the stored value is explicitly set to want, returning
+// immediately if it already is, or returns an error like WaitFor.
+func (c *Comparable[T]) WaitEqual(ctx context.Context, want T) error {
+	_, err := c.WaitFor(ctx, func(storeValue T) bool {
+		return storeValue == want
+	})
+
+	return err
+}
+
+// NewComparable returns a new Comparable with its value explicitly set,
+// configured by opts.
+func NewComparable[T comparable](storeValue T, opts ...Option[T]) *Comparable[T] {
+	var newComparable Comparable[T]
+
+	newComparable.configure(opts)
+	newComparable.useEqualLocked()
+	newComparable.Set(storeValue)
+
+	return &newComparable
+}
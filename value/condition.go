@@ -0,0 +1,58 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "context"
+
+// Cond is a condition over multiple Values, created by Condition.
+type Cond struct {
+	satisfied *Value[bool]
+	stop      func()
+}
+
+// Condition returns a new Cond, which is satisfied while pred returns true.
+// pred is evaluated immediately, and again each time any of deps changes, so it
+// must only depend on the state of deps. Like the compute function of Derive,
+// pred is called without holding the locks of deps, so it may read them, but
+// it must not change them. Stop must be called once the Cond is no longer
+// needed, so pred is no longer evaluated.
+func Condition(pred func() bool, deps ...Watchable) *Cond {
+	satisfied, stop := derive(pred, deps...)
+
+	return &Cond{satisfied: satisfied, stop: stop}
+}
+
+// Satisfied returns a boolean indicating if the condition is satisfied, as of
+// the last evaluation of its predicate.
+func (c *Cond) Satisfied() bool {
+	return c.satisfied.Get()
+}
+
+// Wait blocks until the condition is satisfied, returning immediately if it
+// already is, or the Context is cancelled, in which case a WaitError is
+// returned, like GetWait.
+func (c *Cond) Wait(ctx context.Context) error {
+	_, err := c.satisfied.WaitFor(ctx, func(satisfied bool) bool {
+		return satisfied
+	})
+
+	return err
+}
+
+// Stop stops watching the dependencies of the Cond, so its predicate is no
+// longer evaluated, and it will never be satisfied if it isn't already.
+func (c *Cond) Stop() {
+	c.stop()
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"sync"
+	"time"
+)
+
+// follow calls update, and calls it again each time any of deps changes, until
+// the returned function is called. Calls to update are serialized, which
+// ensures the last call, which observes every change to deps, is also the last
+// to complete.
+func follow(update func(), deps ...Watchable) (cancel func()) {
+	var mu sync.Mutex
+	serialized := func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		update()
+	}
+
+	cancels := make([]func(), len(deps))
+	for i, dep := range deps {
+		cancels[i] = dep.watch(serialized)
+	}
+	serialized()
+
+	return func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// unsetIfSet unsets v, if it is set.
+func unsetIfSet[T any](v *Value[T]) {
+	if v.IsSet() {
+		v.Unset()
+	}
+}
+
+// Derived is a read-only Value that is updated from the Values it is derived
+// from, created by Derive, Map, Combine, Coalesce, or Throttle. Stop must be
+// called once it is no longer needed, so it is no longer updated.
+type Derived[T any] struct {
+	ReadOnly[T]
+	stop func()
+}
+
+// Stop stops updating the Derived, which keeps its current value.
+func (d Derived[T]) Stop() {
+	d.stop()
+}
+
+// Derive returns a Derived that is explicitly set to the result of compute,
+// and set again each time any of deps changes. compute is called without
+// holding the locks of deps, so it may read them, but it must not change them.
+// Deriving a Value from itself, directly or indirectly, deadlocks.
+func Derive[T any](compute func() T, deps ...Watchable) Derived[T] {
+	derived, stop := derive(compute, deps...)
+
+	return Derived[T]{ReadOnly: derived.ReadOnly(), stop: stop}
+}
+
+// derive implements Derive, returning the Value it updates, which Condition
+// also uses, and a function that stops updating it.
+func derive[T any](compute func() T, deps ...Watchable) (derived *Value[T], cancel func()) {
+	derived = NewWith[T]()
+
+	cancel = follow(func() {
+		derived.Set(compute())
+	}, deps...)
+
+	return derived, cancel
+}
+
+// Map returns a Derived that is a live projection of src, explicitly set to the result
+// of calling f with the stored value of src while src is set, and unset while
+// src is unset. It is only changed by src.
+func Map[A, B any](src *Value[A], f func(A) B) Derived[B] {
+	mapped := NewWith[B]()
+
+	stop := follow(func() {
+		stored, ok := src.GetOk()
+		if !ok {
+			unsetIfSet(mapped)
+
+			return
+		}
+
+		mapped.Set(f(stored))
+	}, src)
+
+	return Derived[B]{ReadOnly: mapped.ReadOnly(), stop: stop}
+}
+
+// Combine returns a Derived that is explicitly set to the result of
+// calling f with the stored values of a and b, once both are set, and set again
+// each time either changes. It is unset while either is unset. It is only
+// changed by a and b.
+func Combine[A, B, C any](a *Value[A], b *Value[B], f func(A, B) C) Derived[C] {
+	combined := NewWith[C]()
+
+	stop := follow(func() {
+		storedA, okA := a.GetOk()
+		storedB, okB := b.GetOk()
+		if !okA || !okB {
+			unsetIfSet(combined)
+
+			return
+		}
+
+		combined.Set(f(storedA, storedB))
+	}, a, b)
+
+	return Derived[C]{ReadOnly: combined.ReadOnly(), stop: stop}
+}
+
+// Coalesce returns a Derived whose effective value is the stored value
+// of the first of vs that is explicitly set, in priority order, such as flag,
+// then environment variable, then configuration file. It is updated each time
+// any of vs is set or unset, and is unset while none of vs are set. It is only
+// changed by vs.
+func Coalesce[T any](vs ...*Value[T]) Derived[T] {
+	coalesced := NewWith[T]()
+
+	deps := make([]Watchable, len(vs))
+	for i, v := range vs {
+		deps[i] = v
+	}
+
+	stop := follow(func() {
+		for _, v := range vs {
+			if stored, ok := v.GetOk(); ok {
+				coalesced.Set(stored)
+
+				return
+			}
+		}
+
+		unsetIfSet(coalesced)
+	}, deps...)
+
+	return Derived[T]{ReadOnly: coalesced.ReadOnly(), stop: stop}
+}
+
+// Throttle returns a Derived that is set and unset as src is, but changes at
+// most once per interval, according to the Clock of src. A change of src within
+// interval of the last change of the Derived is delayed until interval has
+// passed, and changes made meanwhile are coalesced, so the Derived always ends
+// with the latest state of src. It is only changed by src. Stop also cancels a
+// delayed change.
+func Throttle[T any](src *Value[T], interval time.Duration) Derived[T] {
+	throttled := NewWith[T]()
+	clock := src.clock()
+
+	var mu sync.Mutex
+	var lastChanged time.Time
+	var delayed Timer
+	var stopped bool
+
+	// the lock must be held when calling apply
+	apply := func() {
+		stored, ok := src.GetOk()
+		switch {
+		case ok:
+			throttled.Set(stored)
+		case throttled.IsSet():
+			throttled.Unset()
+		default:
+			return
+		}
+
+		lastChanged = clock.Now()
+	}
+
+	cancel := follow(func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if delayed != nil || stopped {
+			return
+		}
+
+		wait := lastChanged.Add(interval).Sub(clock.Now())
+		if lastChanged.IsZero() || wait <= 0 {
+			apply()
+
+			return
+		}
+
+		delayed = clock.AfterFunc(wait, func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			delayed = nil
+			if !stopped {
+				apply()
+			}
+		})
+	}, src)
+
+	stop := func() {
+		cancel()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		stopped = true
+		if delayed != nil {
+			delayed.Stop()
+			delayed = nil
+		}
+	}
+
+	return Derived[T]{ReadOnly: throttled.ReadOnly(), stop: stop}
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// Dict is a Value of a map type, with atomic per-key changes. The stored map is
+// replaced rather than modified by SetKey and DeleteKey, so maps returned by Get
+// are safe to read while the Dict is changed.
+type Dict[K comparable, V any] struct {
+	Value[map[K]V]
+}
+
+// updateKeys sets the value explicitly to a copy of the stored map, after it
+// has been modified by fn.
+//
+// updateKeys holds the lock while copying and setting the value.
+func (d *Dict[K, V]) updateKeys(fn func(map[K]V)) {
+	d.mu.Lock()
+	defer d.unlock()
+
+	d.mustMutableLocked()
+
+	newMap := make(map[K]V, len(d.stored))
+	for key, mapValue := range d.stored {
+		newMap[key] = mapValue
+	}
+	fn(newMap)
+
+	d.setLocked(d.mustPrepareLocked(newMap))
+}
+
+// SetKey stores mapValue for key, and sets the value explicitly.
+func (d *Dict[K, V]) SetKey(key K, mapValue V) {
+	d.updateKeys(func(m map[K]V) {
+		m[key] = mapValue
+	})
+}
+
+// DeleteKey removes key, and sets the value explicitly.
+func (d *Dict[K, V]) DeleteKey(key K) {
+	d.updateKeys(func(m map[K]V) {
+		delete(m, key)
+	})
+}
+
+// GetKey returns the value stored for key, and a boolean indicating if key
+// is present.
+func (d *Dict[K, V]) GetKey(key K) (V, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	mapValue, ok := d.stored[key]
+
+	return mapValue, ok
+}
+
+// NewDict returns a new Dict with its value explicitly set to an empty map,
+// configured by opts.
+func NewDict[K comparable, V any](opts ...Option[map[K]V]) *Dict[K, V] {
+	var newDict Dict[K, V]
+
+	newDict.configure(opts)
+	newDict.Set(map[K]V{})
+
+	return &newDict
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// eitherSide identifies which alternative of an Either is set.
+type eitherSide int
+
+const (
+	eitherUnset eitherSide = iota
+	eitherLeft
+	eitherRight
+)
+
+// Either is explicitly set to one of two alternatives, such as inline
+// configuration or the path to a configuration file. Like Optional, it has no
+// synchronization, and can be copied. The zero value is unset.
+type Either[L, R any] struct {
+	left  L
+	right R
+	side  eitherSide
+}
+
+// Left returns an Either explicitly set to the left alternative.
+func Left[L, R any](left L) Either[L, R] {
+	return Either[L, R]{left: left, side: eitherLeft}
+}
+
+// Right returns an Either explicitly set to the right alternative.
+func Right[L, R any](right R) Either[L, R] {
+	return Either[L, R]{right: right, side: eitherRight}
+}
+
+// SetLeft sets the Either explicitly to the left alternative.
+func (e *Either[L, R]) SetLeft(left L) {
+	*e = Left[L, R](left)
+}
+
+// SetRight sets the Either explicitly to the right alternative.
+func (e *Either[L, R]) SetRight(right R) {
+	*e = Right[L](right)
+}
+
+// Unset returns the Either to its unset state.
+func (e *Either[L, R]) Unset() {
+	*e = Either[L, R]{}
+}
+
+// GetLeft returns the left alternative, and a boolean indicating if it is the
+// one that is set.
+func (e Either[L, R]) GetLeft() (L, bool) {
+	return e.left, e.side == eitherLeft
+}
+
+// GetRight returns the right alternative, and a boolean indicating if it is
+// the one that is set.
+func (e Either[L, R]) GetRight() (R, bool) {
+	return e.right, e.side == eitherRight
+}
+
+// IsLeft returns a boolean indicating if the left alternative is set.
+func (e Either[L, R]) IsLeft() bool {
+	return e.side == eitherLeft
+}
+
+// IsRight returns a boolean indicating if the right alternative is set.
+func (e Either[L, R]) IsRight() bool {
+	return e.side == eitherRight
+}
+
+// IsSet returns a boolean indicating if either alternative is set.
+func (e Either[L, R]) IsSet() bool {
+	return e.side != eitherUnset
+}
+
+// Match calls onLeft or onRight with the alternative that is set, and returns
+// a boolean indicating if either was called. Either function may be nil.
+func (e Either[L, R]) Match(onLeft func(L), onRight func(R)) bool {
+	switch e.side {
+	case eitherLeft:
+		if onLeft != nil {
+			onLeft(e.left)
+		}
+	case eitherRight:
+		if onRight != nil {
+			onRight(e.right)
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// MatchEither returns the result of calling onLeft or onRight with the
+// alternative of e that is set, and a boolean indicating if either was called.
+func MatchEither[L, R, X any](e Either[L, R], onLeft func(L) X, onRight func(R) X) (X, bool) {
+	switch e.side {
+	case eitherLeft:
+		return onLeft(e.left), true
+	case eitherRight:
+		return onRight(e.right), true
+	default:
+		var zero X
+
+		return zero, false
+	}
+}
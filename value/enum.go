@@ -0,0 +1,51 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "fmt"
+
+// Enum is a Value that may only be set to one of a fixed set of allowed values.
+// An Enum must be created with NewEnum.
+type Enum[T comparable] struct {
+	Value[T]
+	allowed []T
+}
+
+// NewEnum returns a new unset Enum that may only be set to one of allowed, and
+// is configured by opts. Attempting to set it to any other value is rejected
+// with ErrNotAllowed.
+func NewEnum[T comparable](allowed []T, opts ...Option[T]) *Enum[T] {
+	newEnum := Enum[T]{allowed: append([]T(nil), allowed...)}
+
+	newEnum.configure(append(opts, WithValidator(newEnum.check)))
+
+	return &newEnum
+}
+
+// check returns ErrNotAllowed if storeValue isn't one of the allowed values.
+func (e *Enum[T]) check(storeValue T) error {
+	for _, allowed := range e.allowed {
+		if storeValue == allowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%v not one of %v: %w", storeValue, e.allowed, ErrNotAllowed)
+}
+
+// Allowed returns the values the Enum may be set to.
+func (e *Enum[T]) Allowed() []T {
+	return append([]T(nil), e.allowed...)
+}
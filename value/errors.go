@@ -0,0 +1,109 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotSet is returned when a value is required, but was not explicitly set.
+	ErrNotSet = errors.New("value not explicitly set")
+
+	// ErrReset is returned by waiters when the value they were waiting on was
+	// Reset.
+	ErrReset = errors.New("value reset")
+
+	// ErrExpired is returned by waiters when the value they were waiting on
+	// expired.
+	ErrExpired = errors.New("value expired")
+
+	// ErrOutOfRange is returned when attempting to set a Bounded to a value
+	// outside of its bounds.
+	ErrOutOfRange = errors.New("value out of range")
+
+	// ErrNotAllowed is returned when attempting to set an Enum to a value that
+	// isn't one of its allowed values.
+	ErrNotAllowed = errors.New("value not allowed")
+
+	// ErrZero is returned when attempting to explicitly set a Value configured
+	// with WithNonZero to the zero value of its type.
+	ErrZero = errors.New("value is zero")
+
+	// ErrFrozen is returned when attempting to change a frozen value.
+	ErrFrozen = errors.New("value frozen")
+
+	// ErrClosed is returned by Subscription.Err after the Subscription was
+	// closed.
+	ErrClosed = errors.New("subscription closed")
+
+	// ErrSlowConsumer is wrapped by the errors reported for Subscriptions
+	// configured with WithSlowConsumer, when their receivers fall behind.
+	ErrSlowConsumer = errors.New("slow consumer")
+
+	// ErrWaitCancelled is matched by the errors returned by waiters when their
+	// Context was cancelled before the wait ended.
+	ErrWaitCancelled = errors.New("wait cancelled")
+
+	// ErrWaitDeadline is matched by the errors returned by waiters when the
+	// deadline of their Context passed before the wait ended.
+	ErrWaitDeadline = errors.New("wait deadline exceeded")
+)
+
+// WaitError is returned by waiters when their Context is done before the wait
+// ended. It matches ErrWaitCancelled or ErrWaitDeadline with errors.Is,
+// according to why the Context is done, and unwraps to the error of the
+// Context, so it also matches context.Canceled or context.DeadlineExceeded.
+type WaitError struct {
+	// Err is the error of the Context.
+	Err error
+}
+
+// Error implements error.
+func (e *WaitError) Error() string {
+	return e.reason().Error()
+}
+
+// Unwrap returns the error of the Context.
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports if target is ErrWaitCancelled or ErrWaitDeadline, according to why
+// the Context is done.
+func (e *WaitError) Is(target error) bool {
+	return target == e.reason()
+}
+
+// reason returns ErrWaitDeadline if the deadline of the Context passed, and
+// ErrWaitCancelled otherwise.
+func (e *WaitError) reason() error {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return ErrWaitDeadline
+	}
+
+	return ErrWaitCancelled
+}
+
+// waitError returns err wrapped in a WaitError if it is the error of a done
+// Context, and err otherwise.
+func waitError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &WaitError{Err: err}
+	}
+
+	return err
+}
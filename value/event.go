@@ -0,0 +1,197 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"time"
+)
+
+// EventKind identifies the kind of change an Event describes.
+type EventKind int
+
+const (
+	// EventSet is a change made by explicitly setting the value.
+	EventSet EventKind = iota
+
+	// EventUnset is a change made by unsetting the value, such as by Unset or
+	// Reset.
+	EventUnset
+
+	// EventExpire is a change made by unsetting the value when it expired.
+	EventExpire
+
+	// EventGap is delivered by SubscribeSince in place of changes that are no
+	// longer retained. It isn't a change, and only its Version, which is the
+	// current version of the Value, and Time are meaningful. Consumers should
+	// discard the state they derived from the Value, as though it was unset.
+	EventGap
+)
+
+// Event describes a change of a Value, as delivered to its callbacks and
+// Subscriptions.
+type Event[T any] struct {
+	Kind EventKind
+	// Old and OldSet are the stored value and set state before the change.
+	Old    T
+	OldSet bool
+	// New is the stored value after the change.
+	New T
+	// Version is the version of the Value after the change.
+	Version uint64
+	// Time is when the change was made, according to the Clock of the Value.
+	Time time.Time
+	// Source is the origin of the value, as recorded by SetWithSource.
+	Source string
+}
+
+// Any returns e with its values converted to any, which permits handling the
+// Events of Values of different types together.
+func (e Event[T]) Any() Event[any] {
+	return Event[any]{
+		Kind:    e.Kind,
+		Old:     e.Old,
+		OldSet:  e.OldSet,
+		New:     e.New,
+		Version: e.Version,
+		Time:    e.Time,
+		Source:  e.Source,
+	}
+}
+
+// emitLocked queues the delivery of e to the currently registered handlers,
+// which happens like the notification of listeners, once the lock is released.
+// wake wakes the waiters and listeners of the change. It is called immediately,
+// unless the Value was configured with WithInterceptor, in which case it is
+// called with the lock held once the interceptors pass e along, and not at all
+// if they drop it. The lock must be held by the caller.
+func (v *Value[T]) emitLocked(e Event[T], wake func()) {
+	v.retainLocked(e)
+
+	intercepted := len(v.opts.interceptors) > 0
+	if !intercepted {
+		wake()
+
+		if len(v.handlers) == 0 {
+			return
+		}
+
+		wake = nil
+	}
+
+	handlers := v.handlers
+	if v.blocking == 0 {
+		v.notifyQueue = append(v.notifyQueue, func() {
+			v.dispatch(e, handlers, wake)
+		})
+
+		return
+	}
+
+	delivered := make(chan struct{})
+	v.delivered = append(v.delivered, delivered)
+	v.notifyQueue = append(v.notifyQueue, func() {
+		defer close(delivered)
+
+		v.dispatch(e, handlers, wake)
+	})
+}
+
+// currentEventLocked returns an Event describing the current value, as though
+// it was just set. The lock must be held by the caller.
+func (v *Value[T]) currentEventLocked() Event[T] {
+	return Event[T]{
+		Kind:    EventSet,
+		New:     v.copied(v.stored),
+		Version: v.version,
+		Time:    v.lastSet,
+		Source:  v.source,
+	}
+}
+
+// waitDelivered waits for each of delivered, which must have been taken from
+// the Value while holding its lock, to be closed. The lock must not be held by
+// the caller.
+func waitDelivered(delivered []chan struct{}) {
+	for _, done := range delivered {
+		<-done
+	}
+}
+
+// dispatch passes e through the interceptors configured with WithInterceptor,
+// in order, and then delivers it to handlers, each of which receives its own
+// copy of the values of e. If wake is not nil it is called with the lock held
+// before e is delivered, and any listeners it marks are queued to be notified.
+func (v *Value[T]) dispatch(e Event[T], handlers []handler[T], wake func()) {
+	deliver := func(e Event[T]) {
+		if wake != nil {
+			v.mu.Lock()
+			wake()
+			v.unlockQueued()
+		}
+
+		for _, h := range handlers {
+			copied := e
+			copied.Old = v.copied(e.Old)
+			copied.New = v.copied(e.New)
+
+			h.fn(copied)
+		}
+	}
+
+	interceptors := v.opts.interceptors
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		intercept, next := interceptors[i], deliver
+		deliver = func(e Event[T]) {
+			intercept(e, next)
+		}
+	}
+
+	deliver(e)
+}
+
+// Merged is an Event of one of the Values passed to Merge.
+type Merged struct {
+	// Index is the position of the Value in the arguments to Merge.
+	Index int
+	Event[any]
+}
+
+// Merge returns a channel on which an Event for each subsequent change of any
+// of vs is delivered, until ctx is done, after which the channel is closed.
+// Events of each Value are delivered in the order of its changes, and Events
+// are queued, so changing vs never blocks on a slow receiver.
+func Merge(ctx context.Context, vs ...Watchable) <-chan Merged {
+	var merged Value[Merged]
+	events := merged.Subscribe(ctx)
+
+	cancels := make([]func(), len(vs))
+	for i, v := range vs {
+		i := i
+		cancels[i] = v.watchEvents(func(e Event[any]) {
+			merged.Set(Merged{Index: i, Event: e})
+		})
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}()
+
+	return events
+}
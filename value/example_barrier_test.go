@@ -0,0 +1,39 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleBarrier() {
+	var primary, replica1, replica2 value.Value[string]
+	barrier := value.NewBarrier(2, &primary, &replica1, &replica2)
+
+	primary.Set("db-0")
+	fmt.Println(barrier.Progress())
+
+	go replica2.Set("db-2")
+
+	fmt.Println(barrier.Wait(context.Background()))
+	fmt.Println(barrier.Progress())
+
+	// Output: 1 2
+	// <nil>
+	// 2 2
+}
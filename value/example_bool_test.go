@@ -0,0 +1,35 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleBool_Toggle() {
+	var debug value.Bool
+
+	fmt.Println(debug.Toggle())
+	fmt.Println(debug.Toggle())
+
+	debug.SetTrue()
+	fmt.Println(debug.Get())
+
+	// Output: true
+	// false
+	// true
+}
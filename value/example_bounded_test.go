@@ -0,0 +1,33 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleBounded() {
+	workers := value.NewBounded(1, 64, value.Clamp)
+	workers.Set(1000)
+	fmt.Println(workers.Get())
+
+	ratio := value.NewBounded(0.0, 1.0, value.Reject)
+	fmt.Println(ratio.SetErr(1.5))
+
+	// Output: 64
+	// 1.5 not in [0, 1]: value out of range
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleValue_OnSet() {
+	var v value.Value[string]
+	cancel := v.OnSet(func(storeValue string) {
+		fmt.Println("set to", storeValue)
+	})
+
+	v.Set("a")
+	v.Set("b")
+	cancel()
+	v.Set("c")
+
+	// Output: set to a
+	// set to b
+}
+
+func ExampleWithReplayCurrent() {
+	level := value.New("info")
+	level.OnSet(func(stored string) {
+		fmt.Println("level", stored)
+	}, value.WithReplayCurrent[string]())
+
+	level.Set("debug")
+
+	// Output: level info
+	// level debug
+}
+
+func ExampleAsync() {
+	var v value.Value[int]
+
+	done := make(chan struct{})
+	cancel := v.OnSet(func(storeValue int) {
+		fmt.Println("set to", storeValue)
+		if storeValue == 3 {
+			close(done)
+		}
+	}, value.Async[int]())
+	defer cancel()
+
+	for i := 1; i <= 3; i++ {
+		v.Set(i)
+	}
+	<-done
+
+	// Output: set to 1
+	// set to 2
+	// set to 3
+}
+
+func ExampleValue_OnChange() {
+	v := value.New(1)
+	v.OnChange(func(old, new int) {
+		fmt.Printf("changed from %d to %d\n", old, new)
+	})
+
+	v.Set(2)
+	v.Update(func(current int) int {
+		return current * 10
+	})
+
+	// Output: changed from 1 to 2
+	// changed from 2 to 20
+}
+
+func ExampleValue_OnUnset() {
+	session := value.New("alice")
+	session.OnUnset(func(old string) {
+		fmt.Println("revoked", old)
+	})
+
+	session.Unset()
+	session.Unset()
+
+	// Output: revoked alice
+}
+
+func ExampleValue_OnExpire() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	token := value.NewWith(value.WithClock[string](clock))
+	token.OnExpire(func(old string) {
+		fmt.Println("expired", old)
+	})
+
+	token.SetWithTTL("secret", time.Minute)
+	clock.Advance(time.Minute)
+
+	// Output: expired secret
+}
+
+func ExampleValue_OnEvent() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	port := value.New(80, value.WithClock[int](clock))
+	port.OnEvent(func(e value.Event[int]) {
+		fmt.Println(e.Version, e.Time.Format(time.Kitchen), e.Source, e.Old, "->", e.New)
+	})
+
+	clock.Advance(time.Minute)
+	port.SetWithSource(8080, "flag")
+
+	// Output: 2 12:01PM flag 80 -> 8080
+}
+
+func ExampleValue_NotifyTo() {
+	var config value.Value[string]
+	changed := make(chan struct{}, 1)
+	cancel := config.NotifyTo(changed)
+	defer cancel()
+
+	config.Set("a")
+	config.Set("b")
+
+	select {
+	case <-changed:
+		fmt.Println("changed to", config.Get())
+	default:
+	}
+
+	select {
+	case <-changed:
+	default:
+		fmt.Println("coalesced")
+	}
+
+	// Output: changed to b
+	// coalesced
+}
+
+func ExampleWithContext() {
+	var v value.Value[string]
+	ctx, cancel := context.WithCancel(context.Background())
+
+	v.OnSet(func(stored string) {
+		fmt.Println("set to", stored)
+	}, value.WithContext[string](ctx))
+
+	v.Set("a")
+	cancel()
+	v.Set("b")
+
+	// Output: set to a
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleComparable_CompareAndSwap() {
+	v := value.NewComparable("v1")
+
+	fmt.Println(v.CompareAndSwap("v1", "v2"))
+	fmt.Println(v.CompareAndSwap("v1", "v3"))
+	fmt.Println(v.Get())
+
+	// Output: true
+	// false
+	// v2
+}
+
+func ExampleComparable_Set() {
+	v := value.NewComparable("ready")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// setting the same value doesn't wake the waiter, so it waits until the
+	// context deadline
+	_, err := v.GetWaitTrigger(ctx, func() {
+		v.Set("ready")
+	})
+	fmt.Println(err)
+
+	// Output: wait deadline exceeded
+}
+
+func ExampleComparable_SetChanged() {
+	var v value.Comparable[string]
+
+	for _, endpoint := range []string{"a.example", "a.example", "b.example"} {
+		if v.SetChanged(endpoint) {
+			fmt.Printf("reloading for %s\n", endpoint)
+		}
+	}
+
+	// Output: reloading for a.example
+	// reloading for b.example
+}
+
+func ExampleComparable_OnChange() {
+	c := value.NewComparable("idle")
+	c.OnChange(func(old, new string) {
+		fmt.Println(old, "->", new)
+	})
+
+	c.Set("running")
+	c.Swap("running")
+	c.Set("idle")
+
+	// Output: idle -> running
+	// running -> idle
+}
+
+func ExampleComparable_WaitEqual() {
+	ready := value.NewComparable(false)
+
+	go ready.Set(true)
+
+	fmt.Println(ready.WaitEqual(context.Background(), true))
+
+	// Output: <nil>
+}
+
+func ExampleComparable_Update() {
+	v := value.NewComparable("ready")
+
+	v.Update(func(stored string) string {
+		return stored
+	})
+	v.Swap("ready")
+	v.SetWithSource("ready", "flag")
+
+	fmt.Println(v.Version())
+
+	// Output: 1
+}
+
+func ExampleComparable_Update_zero() {
+	var replicas value.Comparable[int]
+
+	for i := 0; i < 2; i++ {
+		replicas.Update(func(int) int {
+			return 3
+		})
+	}
+	fmt.Println(replicas.Version())
+
+	// Output: 1
+}
+
+func ExampleComparable_TxnSet() {
+	var mode value.Comparable[string]
+	mode.Set("active")
+
+	var txn value.Txn
+	mode.TxnSet(&txn, "active")
+	if err := txn.Commit(); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(mode.Version())
+
+	// Output: 1
+}
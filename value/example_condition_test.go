@@ -0,0 +1,61 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleCondition() {
+	var healthy value.Bool
+	var replicas value.Number[int]
+
+	ready := value.Condition(func() bool {
+		return healthy.Get() && replicas.Get() >= 2
+	}, &healthy, &replicas)
+	defer ready.Stop()
+
+	healthy.SetTrue()
+	replicas.Set(1)
+	fmt.Println(ready.Satisfied())
+
+	go replicas.Inc()
+
+	fmt.Println(ready.Wait(context.Background()), ready.Satisfied())
+
+	// Output: false
+	// <nil> true
+}
+
+func ExampleCond_Stop() {
+	var healthy value.Bool
+
+	evaluations := 0
+	ready := value.Condition(func() bool {
+		evaluations++
+
+		return healthy.Get()
+	}, &healthy)
+
+	ready.Stop()
+	healthy.SetTrue()
+
+	fmt.Println(ready.Satisfied(), evaluations)
+
+	// Output: false 1
+}
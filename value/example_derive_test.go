@@ -0,0 +1,139 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleDerive() {
+	scheme := value.New("https")
+	host := value.New("a.example")
+	port := value.New(443)
+
+	endpoint := value.Derive(func() string {
+		return fmt.Sprintf("%s://%s:%d", scheme.Get(), host.Get(), port.Get())
+	}, scheme, host, port)
+	defer endpoint.Stop()
+	fmt.Println(endpoint.Get())
+
+	host.Set("b.example")
+	port.Set(8443)
+	fmt.Println(endpoint.Get())
+
+	// Output: https://a.example:443
+	// https://b.example:8443
+}
+
+func ExampleMap() {
+	celsius := value.New(20.0)
+	fahrenheit := value.Map(celsius, func(c float64) float64 {
+		return c*9/5 + 32
+	})
+	defer fahrenheit.Stop()
+	fmt.Println(fahrenheit)
+
+	celsius.Set(100)
+	fmt.Println(fahrenheit)
+
+	celsius.Unset()
+	fmt.Println(fahrenheit)
+
+	// Output: explicit(68)
+	// explicit(212)
+	// unset(float64)
+}
+
+func ExampleCombine() {
+	var host value.Value[string]
+	var port value.Value[int]
+
+	address := value.Combine(&host, &port, func(host string, port int) string {
+		return fmt.Sprintf("%s:%d", host, port)
+	})
+	defer address.Stop()
+
+	host.Set("a.example")
+	fmt.Println(address)
+
+	port.Set(8080)
+	fmt.Println(address)
+
+	// Output: unset(string)
+	// explicit(a.example:8080)
+}
+
+func ExampleCoalesce() {
+	var fromFlag, fromEnv, fromFile value.Value[string]
+
+	listen := value.Coalesce(&fromFlag, &fromEnv, &fromFile)
+	defer listen.Stop()
+
+	fromFile.Set(":80")
+	fromEnv.Set(":8080")
+	fmt.Println(listen.Get())
+
+	fromFlag.Set(":9090")
+	fmt.Println(listen.Get())
+
+	fromFlag.Unset()
+	fmt.Println(listen.Get())
+
+	// Output: :8080
+	// :9090
+	// :8080
+}
+
+func ExampleThrottle() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	cpu := value.NewWith(value.WithClock[int](clock))
+	throttled := value.Throttle(cpu, time.Second)
+	defer throttled.Stop()
+
+	cpu.Set(10)
+	fmt.Println(throttled)
+
+	cpu.Set(20)
+	cpu.Set(30)
+	fmt.Println(throttled)
+
+	clock.Advance(time.Second)
+	fmt.Println(throttled)
+
+	// Output: explicit(10)
+	// explicit(10)
+	// explicit(30)
+}
+
+func ExampleDerived_Stop() {
+	replicas := value.New(2)
+	capacity := value.Map(replicas, func(replicas int) int {
+		return replicas * 100
+	})
+
+	replicas.Set(3)
+	fmt.Println(capacity.Get())
+
+	// once stopped, the Derived keeps its value
+	capacity.Stop()
+	replicas.Set(4)
+	fmt.Println(capacity.Get())
+
+	// Output: 300
+	// 300
+}
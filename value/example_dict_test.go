@@ -0,0 +1,35 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleDict() {
+	var limits value.Dict[string, int]
+
+	limits.SetKey("tenant-a", 10)
+	limits.SetKey("tenant-b", 20)
+	limits.DeleteKey("tenant-a")
+
+	fmt.Println(limits.GetKey("tenant-a"))
+	fmt.Println(limits.GetKey("tenant-b"))
+
+	// Output: 0 false
+	// 20 true
+}
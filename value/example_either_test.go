@@ -0,0 +1,54 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleEither() {
+	var inlineOrPath value.Either[string, string]
+
+	inlineOrPath.SetRight("/etc/app/config.yaml")
+
+	inlineOrPath.Match(
+		func(inline string) {
+			fmt.Printf("using inline config: %s\n", inline)
+		},
+		func(path string) {
+			fmt.Printf("reading config from: %s\n", path)
+		},
+	)
+
+	// Output: reading config from: /etc/app/config.yaml
+}
+
+func ExampleMatchEither() {
+	port := value.Left[int, string](8080)
+
+	description, _ := value.MatchEither(port,
+		func(number int) string {
+			return fmt.Sprintf("port number %d", number)
+		},
+		func(name string) string {
+			return fmt.Sprintf("named port %q", name)
+		},
+	)
+	fmt.Println(description)
+
+	// Output: port number 8080
+}
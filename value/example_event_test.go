@@ -0,0 +1,44 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleMerge() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var host value.Value[string]
+	var port value.Value[int]
+	changes := value.Merge(ctx, &host, &port)
+
+	host.Set("localhost")
+	port.Set(8080)
+	host.Unset()
+
+	for i := 0; i < 3; i++ {
+		change := <-changes
+		fmt.Println(change.Index, change.Kind == value.EventSet, change.New)
+	}
+
+	// Output: 0 true localhost
+	// 1 true 8080
+	// 0 false
+}
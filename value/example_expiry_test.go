@@ -0,0 +1,51 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleValue_SetWithTTL() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	token := value.NewWith(value.WithClock[string](clock))
+
+	token.SetWithTTL("secret", time.Minute)
+
+	clock.Advance(59 * time.Second)
+	fmt.Println(token.GetOk())
+
+	clock.Advance(time.Second)
+	fmt.Println(token.GetOk())
+
+	// Output: secret true
+	//  false
+}
+
+func ExampleValue_SetWithTTL_frozen() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	token := value.NewWith(value.WithClock[string](clock))
+
+	token.SetWithTTL("secret", time.Minute)
+	token.Freeze()
+
+	clock.Advance(time.Minute)
+	fmt.Println(token.GetOk())
+
+	// Output: secret true
+}
@@ -0,0 +1,36 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleFuture() {
+	var certificate, key value.Future[string]
+
+	go certificate.Complete("certificate")
+	go key.Fail(errors.New("key not found"))
+
+	fmt.Println(certificate.Await(context.Background()))
+	fmt.Println(key.Await(context.Background()))
+
+	// Output: certificate <nil>
+	//  key not found
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+// describeTimeout only needs to read the timeout, so it accepts a value.Getter.
+func describeTimeout(timeout value.Getter[int]) string {
+	if seconds, ok := timeout.GetOk(); ok {
+		return fmt.Sprintf("timeout: %ds", seconds)
+	}
+
+	return "timeout: none"
+}
+
+func ExampleGetter() {
+	fmt.Println(describeTimeout(value.New(30)))
+	fmt.Println(describeTimeout(value.Optional[int]{}))
+
+	// Output: timeout: 30s
+	// timeout: none
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleValue_MarshalJSON() {
+	type request struct {
+		Name    value.Value[string] `json:"name"`
+		Retries value.Value[int]    `json:"retries"`
+	}
+
+	var req request
+	req.Name.Set("build")
+
+	encoded, _ := json.Marshal(&req)
+	fmt.Println(string(encoded))
+
+	// Output: {"name":"build","retries":null}
+}
+
+func ExampleValue_UnmarshalJSON() {
+	type request struct {
+		Name    value.Value[string] `json:"name"`
+		Retries value.Value[int]    `json:"retries"`
+	}
+
+	var req request
+	if err := json.Unmarshal([]byte(`{"retries":0}`), &req); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(req.Name.String(), req.Retries.String())
+
+	// Output: unset(string) explicit(0)
+}
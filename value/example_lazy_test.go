@@ -0,0 +1,65 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+	"sync"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleLazy() {
+	hostname := value.NewLazy(func() string {
+		fmt.Println("looking up hostname")
+		return "host.example"
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hostname.Get()
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println(hostname.Value())
+
+	// Output: looking up hostname
+	// explicit(host.example)
+}
+
+func ExampleLazy_panic() {
+	config := value.NewLazy(func() string {
+		panic("config file missing")
+	})
+
+	get := func() {
+		defer func() {
+			fmt.Println("recovered:", recover())
+		}()
+
+		config.Get()
+	}
+
+	// the provider isn't called again, but every read panics
+	get()
+	get()
+
+	// Output: recovered: config file missing
+	// recovered: config file missing
+}
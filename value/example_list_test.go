@@ -0,0 +1,46 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleList_Append() {
+	var hosts value.List[string]
+
+	hosts.Append("a.example")
+	hosts.Append("b.example", "c.example")
+
+	fmt.Println(hosts.Len())
+	fmt.Println(hosts.Get())
+
+	// Output: 3
+	// [a.example b.example c.example]
+}
+
+func ExampleList_Append_capacity() {
+	items := make([]int, 0, 10)
+	l := value.NewList(items)
+	l.Append(1)
+
+	// the List doesn't share the spare capacity of items
+	items = append(items, 99)
+	fmt.Println(l.Get(), items)
+
+	// Output: [1] [99]
+}
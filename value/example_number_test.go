@@ -0,0 +1,42 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+	"sync"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleNumber() {
+	var requests value.Number[uint64]
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			requests.Inc()
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println(requests.Dec())
+	fmt.Println(requests.Add(5))
+
+	// Output: 9
+	// 14
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+type listOptions struct {
+	limit  value.Optional[int]
+	offset value.Optional[int]
+}
+
+func ExampleOptional() {
+	opts := listOptions{
+		limit: value.Some(0),
+	}
+
+	fmt.Println(opts.limit.GetOk())
+	fmt.Println(opts.offset.GetOk())
+
+	fmt.Println(opts.limit.Value())
+
+	// Output: 0 true
+	// 0 false
+	// explicit(0)
+}
@@ -0,0 +1,246 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleWithValidator() {
+	port := value.NewWith(value.WithValidator(func(port int) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d out of range", port)
+		}
+
+		return nil
+	}))
+
+	fmt.Println(port.SetErr(80))
+	fmt.Println(port.SetErr(80000))
+	fmt.Println(port.Get())
+
+	// Output: <nil>
+	// port 80000 out of range
+	// 80
+}
+
+func ExampleWithNonZero() {
+	replicas := value.NewWith(value.WithNonZero[int]())
+
+	fmt.Println(replicas.SetErr(0))
+	fmt.Println(replicas.IsSet())
+
+	// Output: int: value is zero
+	// false
+}
+
+func ExampleWithNormalizer() {
+	username := value.NewWith(
+		value.WithNormalizer(strings.TrimSpace),
+		value.WithNormalizer(strings.ToLower),
+	)
+
+	username.Set("  Admin ")
+	fmt.Printf("%q\n", username.Get())
+
+	// Output: "admin"
+}
+
+func ExampleWithDefault() {
+	timeout := value.NewWith(value.WithDefault(30 * time.Second))
+	fmt.Println(timeout.Get())
+	fmt.Println(timeout.GetOk())
+
+	timeout.Set(time.Minute)
+	timeout.Unset()
+	fmt.Println(timeout.Get())
+
+	// Output: 30s
+	// 30s false
+	// 30s
+}
+
+func ExampleWithClock() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	v := value.NewWith(value.WithClock[string](clock))
+
+	fmt.Println(v.LastSet())
+
+	v.Set("configured")
+	fmt.Println(v.LastSet())
+
+	// Output: 0001-01-01 00:00:00 +0000 UTC false
+	// 2022-06-01 12:00:00 +0000 UTC true
+}
+
+func ExampleWithHistory() {
+	v := value.NewWith(value.WithHistory[int](2))
+
+	for i := 1; i <= 4; i++ {
+		v.Set(i)
+	}
+
+	previous, _ := v.Previous()
+	fmt.Printf("changed from %d to %d\n", previous, v.Get())
+	fmt.Println(v.History())
+
+	// Output: changed from 3 to 4
+	// [2 3]
+}
+
+func ExampleWithTTL() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	lease := value.New("lease-1",
+		value.WithClock[string](clock),
+		value.WithTTL[string](time.Minute),
+	)
+
+	clock.Advance(time.Minute)
+	fmt.Println(lease.IsSet())
+
+	lease.Set("lease-2")
+	fmt.Println(lease.IsSet())
+
+	// Output: false
+	// true
+}
+
+func ExampleWithEqual() {
+	tags := value.New([]string{"a", "b"}, value.WithEqual(func(a, b []string) bool {
+		return strings.Join(a, ",") == strings.Join(b, ",")
+	}))
+
+	fmt.Println(tags.SetChanged([]string{"a", "b"}))
+	fmt.Println(tags.SetChanged([]string{"a", "c"}))
+	fmt.Println(tags.Version())
+
+	// Output: false
+	// true
+	// 2
+}
+
+func ExampleWithCopy() {
+	copyInts := func(ints []int) []int {
+		return append([]int(nil), ints...)
+	}
+
+	ports := []int{80, 443}
+	v := value.New(ports, value.WithCopy(copyInts))
+
+	ports[0] = 8080
+	v.Get()[1] = 8443
+
+	fmt.Println(v.Get())
+
+	// Output: [80 443]
+}
+
+func ExampleWithCopy_history() {
+	copyInts := func(ints []int) []int {
+		return append([]int(nil), ints...)
+	}
+
+	v := value.New([]int{80}, value.WithCopy(copyInts), value.WithHistory[[]int](2))
+	old, _ := v.Swap([]int{443})
+
+	old[0] = 8080
+	previous, _ := v.Previous()
+	previous[0] = 8443
+
+	fmt.Println(v.History())
+
+	// Output: [[80]]
+}
+
+func ExampleWithInterceptor() {
+	temperature := value.NewWith(value.WithInterceptor(func(e value.Event[int], next func(value.Event[int])) {
+		if e.Kind == value.EventSet && e.New < -273 {
+			fmt.Println("dropped", e.New)
+
+			return
+		}
+
+		next(e)
+	}))
+	temperature.OnSet(func(storeValue int) {
+		fmt.Println("set to", storeValue)
+	})
+
+	temperature.Set(21)
+	temperature.Set(-300)
+
+	// Output: set to 21
+	// dropped -300
+}
+
+func ExampleWithInterceptor_waiters() {
+	temperature := value.NewWith(value.WithInterceptor(func(e value.Event[int], next func(value.Event[int])) {
+		fmt.Println("intercepted", e.New)
+
+		if e.New >= -273 {
+			next(e)
+		}
+	}))
+
+	changed := temperature.Changed()
+	temperature.Set(-300)
+
+	select {
+	case <-changed:
+		fmt.Println("woken")
+	default:
+		fmt.Println("not woken")
+	}
+
+	temperature.Set(21)
+	<-changed
+	fmt.Println("woken")
+
+	// Output: intercepted -300
+	// not woken
+	// intercepted 21
+	// woken
+}
+
+func ExampleWithWakePolicy() {
+	jobs := value.NewWith(value.WithWakePolicy[string](value.WakeOne))
+	second := make(chan string)
+
+	first, _ := jobs.GetWaitTrigger(context.Background(), func() {
+		waiting := make(chan struct{})
+		go func() {
+			job, _ := jobs.GetWaitTrigger(context.Background(), func() {
+				close(waiting)
+			})
+			second <- job
+		}()
+		<-waiting
+
+		// only the first waiter is woken
+		jobs.Set("build")
+	})
+	fmt.Println("first waiter got", first)
+
+	jobs.Set("test")
+	fmt.Println("second waiter got", <-second)
+
+	// Output: first waiter got build
+	// second waiter got test
+}
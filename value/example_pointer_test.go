@@ -0,0 +1,48 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+type updateRequest struct {
+	Name *string
+	Tags *[]string
+}
+
+func ExampleFromPtr() {
+	name := "renamed"
+	request := updateRequest{Name: &name}
+
+	fmt.Println(value.FromPtr(request.Name))
+	fmt.Println(value.FromPtr(request.Tags))
+
+	// Output: explicit(renamed)
+	// unset([]string)
+}
+
+func ExampleValue_Ptr() {
+	var v value.Value[int]
+	fmt.Println(v.Ptr() == nil)
+
+	v.Set(0)
+	fmt.Println(*v.Ptr())
+
+	// Output: true
+	// 0
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleReaper() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	reaper := value.NewReaper(clock)
+
+	short := value.NewWith(value.WithReaper[string](reaper))
+	long := value.NewWith(value.WithReaper[string](reaper))
+
+	short.SetWithTTL("short", time.Minute)
+	long.SetWithTTL("long", time.Hour)
+
+	clock.Advance(time.Minute)
+	fmt.Println(short.IsSet(), long.IsSet())
+
+	clock.Advance(time.Hour)
+	fmt.Println(short.IsSet(), long.IsSet())
+
+	// Output: false true
+	// false false
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleResult() {
+	var config value.Result[string]
+
+	fmt.Println(config.Get())
+
+	fmt.Println(config.GetWaitTrigger(context.Background(), func() {
+		go config.SetErr(errors.New("config file not found"))
+	}))
+
+	fmt.Println(config.GetWaitTrigger(context.Background(), func() {
+		go config.Set("loaded")
+	}))
+
+	// Output: string: value not explicitly set
+	//  config file not found
+	// loaded <nil>
+}
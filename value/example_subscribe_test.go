@@ -0,0 +1,333 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleValue_Subscribe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var v value.Value[int]
+	updates := v.Subscribe(ctx)
+
+	for i := 1; i <= 3; i++ {
+		v.Set(i)
+	}
+
+	for i := 0; i < 3; i++ {
+		fmt.Println(<-updates)
+	}
+
+	// Output: 1
+	// 2
+	// 3
+}
+
+func ExampleSubscription() {
+	var v value.Value[string]
+	sub := v.NewSubscription(context.Background())
+
+	v.Set("a")
+	v.Set("b")
+	v.Freeze()
+
+	for update := range sub.C() {
+		fmt.Println(update)
+	}
+	fmt.Println(sub.Err())
+
+	// Output: a
+	// b
+	// value frozen
+}
+
+func ExampleSubscription_Close() {
+	var v value.Value[int]
+	sub := v.NewSubscription(context.Background())
+
+	v.Set(1)
+	sub.Close()
+
+	_, ok := <-sub.C()
+	fmt.Println(ok, sub.Err())
+
+	// Output: false subscription closed
+}
+
+func ExampleWithBuffer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var progress value.Value[int]
+	latest := progress.Subscribe(ctx, value.WithBuffer[int](1, value.KeepLatest))
+	recent := progress.Subscribe(ctx, value.WithBuffer[int](2, value.DropOldest))
+
+	for i := 1; i <= 5; i++ {
+		progress.Set(i)
+	}
+
+	fmt.Println(<-latest)
+	fmt.Println(<-recent, <-recent)
+
+	// Output: 5
+	// 4 5
+}
+
+func ExampleWithBuffer_block() {
+	var v value.Value[int]
+	sub := v.NewSubscription(context.Background(), value.WithBuffer[int](0, value.Block))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for update := range sub.C() {
+			fmt.Println(update)
+		}
+	}()
+
+	for i := 1; i <= 3; i++ {
+		v.Set(i)
+	}
+	v.Freeze()
+	<-done
+
+	// Output: 1
+	// 2
+	// 3
+}
+
+func ExampleWithCurrent() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	level := value.New("info")
+	updates := level.Subscribe(ctx, value.WithCurrent[string]())
+
+	fmt.Println(<-updates)
+
+	level.Set("debug")
+	fmt.Println(<-updates)
+
+	// Output: info
+	// debug
+}
+
+func ExampleWithFilter() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var temperature value.Number[int]
+	alerts := temperature.Subscribe(ctx, value.WithFilter(func(t int) bool {
+		return t > 30
+	}))
+
+	for _, t := range []int{25, 31, 28, 35} {
+		temperature.Set(t)
+	}
+
+	fmt.Println(<-alerts)
+	fmt.Println(<-alerts)
+
+	// Output: 31
+	// 35
+}
+
+func ExampleWithDelivery() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var position value.Value[int]
+	latest := position.Subscribe(ctx, value.WithDelivery[int](value.LatestOnly))
+
+	for i := 1; i <= 100; i++ {
+		position.Set(i)
+	}
+
+	fmt.Println(<-latest)
+
+	// Output: 100
+}
+
+func ExampleWithSlowConsumer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var v value.Value[int]
+	sub := v.NewSubscription(ctx,
+		value.WithBuffer[int](1, value.DropOldest),
+		value.WithSlowConsumer(time.Second, func(s *value.Subscription[int], err error) {
+			fmt.Println(err)
+		}),
+	)
+
+	v.Set(1)
+	v.Set(2)
+	v.Set(3)
+
+	fmt.Println(sub.Dropped(), <-sub.C())
+
+	// Output: slow consumer: buffer full, discarded 1
+	// slow consumer: buffer full, discarded 1
+	// 2 3
+}
+
+func ExampleValue_NewEventSubscription() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var v value.Value[string]
+	events := v.NewEventSubscription(ctx)
+
+	v.Set("a")
+	v.Unset()
+
+	for i := 0; i < 2; i++ {
+		e := <-events.C()
+		fmt.Println(e.Kind == value.EventSet, e.Version, e.Old, e.New)
+	}
+
+	// Output: true 1  a
+	// false 2 a
+}
+
+func ExampleValue_SubscribeSince() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stage := value.NewWith(value.WithHistory[string](2))
+	for _, s := range []string{"fetch", "build", "test", "deploy"} {
+		stage.Set(s)
+	}
+
+	// the changes since version 2 are retained
+	resumed := stage.SubscribeSince(ctx, 2)
+	for i := 0; i < 2; i++ {
+		e := <-resumed.C()
+		fmt.Println(e.Version, e.New)
+	}
+
+	// the change made at version 2 is no longer retained
+	resumed = stage.SubscribeSince(ctx, 1)
+	gap := <-resumed.C()
+	fmt.Println(gap.Version, gap.Kind == value.EventGap)
+	current := <-resumed.C()
+	fmt.Println(current.Version, current.New)
+
+	// Output: 3 test
+	// 4 deploy
+	// 4 true
+	// 4 deploy
+}
+
+func ExampleSubscription_Pause() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var config value.Value[string]
+	sub := config.NewSubscription(ctx, value.WithDelivery[string](value.LatestOnly))
+
+	sub.Pause()
+	for _, step := range []string{"partial-1", "partial-2", "complete"} {
+		config.Set(step)
+	}
+	sub.Resume()
+
+	fmt.Println(<-sub.C(), sub.Dropped())
+
+	// Output: complete 2
+}
+
+func ExampleSubscription_Resume() {
+	var config value.Value[string]
+	sub := config.NewSubscription(context.Background(), value.WithDelivery[string](value.LatestOnly))
+
+	sub.Pause()
+	config.Set("partial")
+	sub.Close()
+	sub.Resume()
+
+	_, ok := <-sub.C()
+	fmt.Println(ok, sub.Err())
+
+	// Output: false subscription closed
+}
+
+func ExampleSubscription_Pause_closed() {
+	var config value.Value[string]
+	sub := config.NewSubscription(context.Background(), value.WithDelivery[string](value.LatestOnly))
+
+	config.Set("loaded")
+	sub.Close()
+
+	// pausing and resuming have no effect once the Subscription has ended
+	sub.Pause()
+	sub.Resume()
+
+	_, ok := <-sub.C()
+	fmt.Println(ok, sub.Err())
+
+	// Output: false subscription closed
+}
+
+func ExampleSubscription_Pause_cancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var config value.Value[string]
+	sub := config.NewSubscription(ctx, value.WithBuffer[string](1, value.DropOldest))
+
+	sub.Pause()
+	config.Set("partial")
+	cancel()
+
+	// wait for the Subscription to end
+	for range sub.C() {
+	}
+
+	sub.Resume()
+	sub.Pause()
+
+	fmt.Println(sub.Err())
+
+	// Output: context canceled
+}
+
+func ExampleWithBuffer_backpressure() {
+	var v value.Value[string]
+	sub := v.NewSubscription(context.Background(), value.WithDelivery[string](value.EveryValue))
+
+	// the Subscription holds "a" until it is received
+	v.Set("a")
+
+	// setting again would wait for "a" to be received
+	fmt.Println(v.TrySet("b"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fmt.Println(v.SetContext(ctx, "c"))
+
+	fmt.Println(<-sub.C(), <-sub.C())
+
+	// Output: false
+	// context deadline exceeded
+	// a c
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleTxn() {
+	host := value.New("a.example")
+	port := value.New(80, value.WithValidator(func(port int) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d out of range", port)
+		}
+
+		return nil
+	}))
+
+	var txn value.Txn
+	value.TxnSet(&txn, host, "b.example")
+	value.TxnSet(&txn, port, 80000)
+	fmt.Println(txn.Commit())
+	fmt.Println(host.Get(), port.Get())
+
+	value.TxnSet(&txn, host, "b.example")
+	value.TxnSet(&txn, port, 8080)
+	fmt.Println(txn.Commit())
+
+	gotHost := value.TxnGet(&txn, host)
+	gotPort := value.TxnGet(&txn, port)
+	fmt.Println(txn.Commit())
+	fmt.Println(gotHost.Get(), gotPort.Get())
+
+	// Output: port 80000 out of range
+	// a.example 80
+	// <nil>
+	// <nil>
+	// b.example 8080
+}
+
+func ExampleTxn_derived() {
+	host := value.New("a.example")
+	port := value.New(80)
+	address := value.Derive(func() string {
+		return fmt.Sprintf("%s:%d", host.Get(), port.Get())
+	}, host, port)
+	defer address.Stop()
+
+	var txn value.Txn
+	value.TxnSet(&txn, host, "b.example")
+	value.TxnSet(&txn, port, 8080)
+	if err := txn.Commit(); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(address.Get())
+
+	// Output: b.example:8080
+}
@@ -16,6 +16,7 @@ package value_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -79,3 +80,415 @@ func ExampleValue() {
 	// new temperature value: 11
 	// new triggered humidity value: 12
 }
+
+func ExampleValue_Unset() {
+	v := value.New(10)
+	fmt.Println(v.GetOk())
+
+	v.Unset()
+	fmt.Println(v.GetOk())
+
+	// Output: 10 true
+	// 0 false
+}
+
+func ExampleValue_IsSet() {
+	var v value.Value[string]
+	fmt.Println(v.IsSet())
+
+	v.Set("")
+	fmt.Println(v.IsSet())
+
+	// Output: false
+	// true
+}
+
+func ExampleValue_SetIfUnset() {
+	var v value.Value[string]
+
+	fmt.Println(v.SetIfUnset("first"))
+	fmt.Println(v.SetIfUnset("second"))
+	fmt.Println(v.Get())
+
+	// Output: true
+	// false
+	// first
+}
+
+func ExampleValue_GetOrSet() {
+	var v value.Value[int]
+
+	fmt.Println(v.GetOrSet(1))
+	fmt.Println(v.GetOrSet(2))
+
+	// Output: 1
+	// 1
+}
+
+func ExampleValue_Swap() {
+	var v value.Value[int]
+
+	fmt.Println(v.Swap(1))
+	fmt.Println(v.Swap(2))
+	fmt.Println(v.Get())
+
+	// Output: 0 false
+	// 1 true
+	// 2
+}
+
+func ExampleValue_Update() {
+	v := value.New([]string{"a"})
+
+	v.Update(func(stored []string) []string {
+		return append(stored, "b")
+	})
+	fmt.Println(v.Get())
+
+	// Output: [a b]
+}
+
+func ExampleValue_UpdateErr() {
+	v := value.New(1)
+
+	err := v.UpdateErr(func(stored int) (int, error) {
+		if stored > 0 {
+			return 0, fmt.Errorf("refusing to update positive value %d", stored)
+		}
+
+		return stored + 1, nil
+	})
+	fmt.Println(err)
+	fmt.Println(v.Get())
+
+	// Output: refusing to update positive value 1
+	// 1
+}
+
+func ExampleValue_GetOr() {
+	var v value.Value[string]
+	fmt.Println(v.GetOr("default"))
+
+	v.Set("explicit")
+	fmt.Println(v.GetOr("default"))
+
+	// Output: default
+	// explicit
+}
+
+func ExampleValue_GetOrElse() {
+	var v value.Value[string]
+
+	lookup := func() string {
+		fmt.Println("looking up default")
+		return "looked up"
+	}
+
+	fmt.Println(v.GetOrElse(lookup))
+
+	v.Set("explicit")
+	fmt.Println(v.GetOrElse(lookup))
+
+	// Output: looking up default
+	// looked up
+	// explicit
+}
+
+func ExampleValue_MustGet() {
+	defer func() {
+		fmt.Println(recover())
+	}()
+
+	var v value.Value[int]
+	v.MustGet()
+
+	// Output: int: value not explicitly set
+}
+
+func ExampleValue_GetErr() {
+	var v value.Value[int]
+
+	if _, err := v.GetErr(); errors.Is(err, value.ErrNotSet) {
+		fmt.Println(err)
+	}
+
+	// Output: int: value not explicitly set
+}
+
+func ExampleValue_Reset() {
+	v := value.New("token")
+
+	_, err := v.GetWaitTrigger(context.Background(), v.Reset)
+	fmt.Println(err)
+	fmt.Println(v.IsSet())
+
+	// Output: value reset
+	// false
+}
+
+func ExampleValue_Clone() {
+	original := value.New("original")
+	clone := original.Clone()
+
+	original.Set("changed")
+	fmt.Println(clone.Get())
+
+	// Output: original
+}
+
+func ExampleValue_Clone_ttl() {
+	clock := value.NewManualClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	lease := value.New("lease-1",
+		value.WithClock[string](clock),
+		value.WithTTL[string](time.Minute),
+	)
+
+	clock.Advance(30 * time.Second)
+	clone := lease.Clone()
+
+	// the clone expires with the original
+	clock.Advance(30 * time.Second)
+	fmt.Println(lease.IsSet(), clone.IsSet())
+
+	// Output: false false
+}
+
+func ExampleValue_Freeze() {
+	v := value.New("startup")
+	v.Freeze()
+
+	if err := v.SetErr("late write"); errors.Is(err, value.ErrFrozen) {
+		fmt.Println(err)
+	}
+	fmt.Println(v.Get())
+
+	// Output: string: value frozen
+	// startup
+}
+
+func ExampleValue_SetChangedFunc() {
+	var v value.Value[[]string]
+
+	sameLength := func(a, b []string) bool {
+		return len(a) == len(b)
+	}
+
+	fmt.Println(v.SetChangedFunc([]string{"a"}, sameLength))
+	fmt.Println(v.SetChangedFunc([]string{"b"}, sameLength))
+	fmt.Println(v.Get())
+
+	// Output: true
+	// false
+	// [a]
+}
+
+func ExampleValue_String() {
+	var v value.Value[float32]
+	fmt.Println(&v)
+
+	v.Set(10)
+	fmt.Println(&v)
+
+	// Output: unset(float32)
+	// explicit(10)
+}
+
+func ExampleValue_Version() {
+	var v value.Value[int]
+	fmt.Println(v.Version())
+
+	v.Set(1)
+	v.Set(2)
+	v.Unset()
+	fmt.Println(v.Version())
+
+	// Output: 0
+	// 3
+}
+
+func ExampleValue_SetWithSource() {
+	var listen value.Value[string]
+
+	listen.SetWithSource(":8080", "env:LISTEN_ADDR")
+	listen.SetWithSource(":9090", "flag:-listen")
+	fmt.Println(listen.Get(), listen.Source())
+
+	// Output: :9090 flag:-listen
+}
+
+func ExampleValue_SetFailed() {
+	v := value.New("v1")
+
+	_, err := v.GetWaitTrigger(context.Background(), func() {
+		v.SetFailed(errors.New("fetching v2: connection refused"))
+	})
+	fmt.Println(err)
+	fmt.Println(v.Get(), v.LastError())
+
+	v.Set("v2")
+	fmt.Println(v.Get(), v.LastError())
+
+	// Output: fetching v2: connection refused
+	// v1 fetching v2: connection refused
+	// v2 <nil>
+}
+
+func ExampleValue_Peek() {
+	sampleRate := value.New(0.1)
+
+	if rate, ok := sampleRate.Peek(); ok {
+		fmt.Println(rate)
+	}
+
+	// Output: 0.1
+}
+
+func ExampleValue_TrySet() {
+	var queueDepth value.Value[int]
+
+	if !queueDepth.TrySet(42) {
+		fmt.Println("skipped update under contention")
+	}
+	fmt.Println(queueDepth.Get())
+
+	// Output: 42
+}
+
+func ExampleValue_SetContext() {
+	v := value.New(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// a slow update holds the lock longer than the writer is willing to wait
+	locked, release, updated := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(updated)
+
+		v.Update(func(stored int) int {
+			close(locked)
+			<-release
+			return stored + 1
+		})
+	}()
+	<-locked
+
+	fmt.Println(v.SetContext(ctx, 10))
+	close(release)
+	<-updated
+	fmt.Println(v.Get())
+
+	// Output: context deadline exceeded
+	// 2
+}
+
+func ExampleValue_WithFallback() {
+	global := value.New(100)
+	tenant := value.NewWith[int]().WithFallback(global)
+	fmt.Println(tenant.GetOk())
+
+	tenant.Set(10)
+	fmt.Println(tenant.GetOk())
+	fmt.Println(global.Get())
+
+	// Output: 100 true
+	// 10 true
+	// 100
+}
+
+func ExampleValue_GetAndUnset() {
+	token := value.New("one-time-token")
+
+	fmt.Println(token.GetAndUnset())
+	fmt.Println(token.GetAndUnset())
+
+	// Output: one-time-token true
+	//  false
+}
+
+func ExampleValue_SetIf() {
+	var latest value.Value[int]
+
+	newer := func(version int) bool {
+		return latest.SetIf(version, func(current int, set bool) bool {
+			return !set || version > current
+		})
+	}
+
+	fmt.Println(newer(3))
+	fmt.Println(newer(2))
+	fmt.Println(latest.Get())
+
+	// Output: true
+	// false
+	// 3
+}
+
+func ExampleValue_GetVersioned() {
+	config := value.New("v1")
+
+	cached, cachedVersion, _ := config.GetVersioned()
+	config.Set("v2")
+
+	if config.Version() != cachedVersion {
+		cached, cachedVersion, _ = config.GetVersioned()
+	}
+	fmt.Println(cached, cachedVersion)
+
+	// Output: v2 2
+}
+
+func ExampleValue_Equal() {
+	var got, want value.Value[int]
+	fmt.Println(got.Equal(&want))
+
+	got.Set(10)
+	fmt.Println(got.Equal(&want))
+
+	want.Set(10)
+	fmt.Println(got.Equal(&want))
+
+	// Output: true
+	// false
+	// true
+}
+
+func ExampleValue_GetWaitOk() {
+	var count value.Value[int]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stored, ok, err := count.GetWaitOk(ctx)
+	fmt.Println(stored, ok, err)
+
+	// Output: 0 false wait cancelled
+}
+
+func ExampleValue_GetWait() {
+	var config value.Value[string]
+
+	var waiting, done sync.WaitGroup
+	results := make([]string, 3)
+	for i := range results {
+		i := i
+		waiting.Add(1)
+		done.Add(1)
+
+		go func() {
+			defer done.Done()
+
+			// waiting.Done is called once the goroutine is waiting, so the
+			// Set below wakes every goroutine
+			results[i], _ = config.GetWaitTrigger(context.Background(), waiting.Done)
+		}()
+	}
+
+	waiting.Wait()
+	config.Set("loaded")
+	done.Wait()
+
+	fmt.Println(results)
+
+	// Output: [loaded loaded loaded]
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"fmt"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+// plugin may read the configuration it is given, but can't change it.
+type plugin struct {
+	endpoint value.ReadOnly[string]
+}
+
+func ExampleValue_ReadOnly() {
+	endpoint := value.New("https://a.example")
+	p := plugin{endpoint: endpoint.ReadOnly()}
+
+	endpoint.Set("https://b.example")
+	fmt.Println(p.endpoint.Get())
+
+	// Output: https://b.example
+}
+
+func ExampleValue_Writer() {
+	var latest value.Value[int]
+
+	produce := func(out value.Writer[int]) {
+		for i := 1; i <= 3; i++ {
+			out.Set(i)
+		}
+	}
+	produce(latest.Writer())
+
+	fmt.Println(latest.Get())
+
+	// Output: 3
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.incompletion.ist/explicit/value"
+)
+
+func ExampleValue_WaitSet() {
+	var config value.Value[string]
+
+	go config.Set("loaded")
+
+	got, err := config.WaitSet(context.Background())
+	fmt.Println(got, err)
+
+	got, err = config.WaitSet(context.Background())
+	fmt.Println(got, err)
+
+	// Output: loaded <nil>
+	// loaded <nil>
+}
+
+func ExampleValue_WaitOr() {
+	var endpoint value.Value[string]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	fmt.Println(endpoint.WaitOr(ctx, "localhost:8080"))
+
+	endpoint.Set("api.example.com:443")
+	fmt.Println(endpoint.WaitOr(context.Background(), "localhost:8080"))
+
+	// Output: localhost:8080
+	// api.example.com:443
+}
+
+func ExampleValue_WaitFor() {
+	var replicas value.Value[int]
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			replicas.Set(i)
+		}
+	}()
+
+	got, err := replicas.WaitFor(context.Background(), func(count int) bool {
+		return count >= 3
+	})
+	fmt.Println(got >= 3, err)
+
+	// Output: true <nil>
+}
+
+func ExampleValue_WaitChangedSince() {
+	v := value.New("first")
+	_, seen, _ := v.GetVersioned()
+
+	v.Set("second")
+	v.Set("third")
+
+	got, seen, err := v.WaitChangedSince(context.Background(), seen)
+	fmt.Println(got, seen, err)
+
+	go v.Set("fourth")
+
+	got, seen, err = v.WaitChangedSince(context.Background(), seen)
+	fmt.Println(got, seen, err)
+
+	// Output: third 3 <nil>
+	// fourth 4 <nil>
+}
+
+func ExampleValue_WaitTimeout() {
+	v := value.New(5)
+
+	got, err := v.WaitTimeout(time.Millisecond)
+	fmt.Println(got, err)
+
+	// Output: 5 wait deadline exceeded
+}
+
+func ExampleValue_Changed() {
+	type snapshot struct {
+		entries [1024]int
+		count   int
+	}
+
+	var index value.Value[snapshot]
+	changed := index.Changed()
+
+	go index.Update(func(s snapshot) snapshot {
+		s.count++
+
+		return s
+	})
+
+	<-changed
+	fmt.Println(index.Get().count)
+
+	// Output: 1
+}
+
+func ExampleWaitAny() {
+	var shutdown value.Bool
+	var reload value.Value[string]
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				reload.Set("config.yaml")
+			}
+		}
+	}()
+
+	index, err := value.WaitAny(context.Background(), &shutdown, &reload)
+	fmt.Println(index, err)
+
+	// Output: 1 <nil>
+}
+
+func ExampleWaitAll() {
+	var listenAddr, certFile value.Value[string]
+	listenAddr.Set(":8443")
+
+	go certFile.Set("server.crt")
+
+	fmt.Println(value.WaitAll(context.Background(), &listenAddr, &certFile))
+
+	// Output: <nil>
+}
+
+func ExampleWaitError() {
+	var config value.Value[string]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := config.GetWait(ctx)
+	fmt.Println(errors.Is(err, value.ErrWaitDeadline), errors.Is(err, value.ErrWaitCancelled))
+
+	var waitErr *value.WaitError
+	if errors.As(err, &waitErr) {
+		fmt.Println(waitErr.Err)
+	}
+
+	// Output: true false
+	// context deadline exceeded
+}
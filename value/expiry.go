@@ -0,0 +1,77 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "time"
+
+// SetWithTTL is like Set, but the value automatically reverts to unset after
+// ttl has elapsed, waking waiters, which will return ErrExpired. Changing the
+// value before then cancels the expiration.
+//
+// Expirations are handled by the Value's Reaper, and are due according to the
+// Reaper's Clock.
+func (v *Value[T]) SetWithTTL(storeValue T, ttl time.Duration) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	v.setLocked(v.mustPrepareLocked(storeValue))
+	v.scheduleExpiryLocked(ttl)
+}
+
+// scheduleExpiryLocked replaces any pending expiration with one for the current
+// version that is due after ttl. The lock must be held by the caller.
+func (v *Value[T]) scheduleExpiryLocked(ttl time.Duration) {
+	v.cancelExpiryLocked()
+
+	expireVersion := v.version
+	v.expiresAt = v.reaper().clock.Now().Add(ttl)
+	v.cancelExpiry = v.reaper().schedule(ttl, func() {
+		v.expire(expireVersion)
+	})
+}
+
+// reaper returns the Reaper configured for the Value, or the default Reaper.
+func (v *Value[T]) reaper() *Reaper {
+	if v.opts.reaper == nil {
+		return defaultReaper
+	}
+
+	return v.opts.reaper
+}
+
+// expire unsets the value and wakes waiters with ErrExpired, if the value
+// hasn't changed since expireVersion, and hasn't been frozen.
+//
+// expire acquires the lock prior to checking the version.
+func (v *Value[T]) expire(expireVersion uint64) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if v.frozen || v.version != expireVersion {
+		return
+	}
+
+	v.unsetLocked(EventExpire)
+	v.drainLocked(ErrExpired)
+}
+
+// cancelExpiryLocked cancels any pending expiration. The lock must be held by
+// the caller.
+func (v *Value[T]) cancelExpiryLocked() {
+	if v.cancelExpiry != nil {
+		v.cancelExpiry()
+		v.cancelExpiry = nil
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is a value that will be provided in the future, or will never arrive
+// because producing it failed. The zero value is an incomplete Future.
+type Future[T any] struct {
+	outcome Value[outcome[T]]
+
+	initDone sync.Once
+	done     chan struct{}
+}
+
+// doneChannel returns the channel that is closed when the Future is completed,
+// initializing it if needed.
+func (f *Future[T]) doneChannel() chan struct{} {
+	f.initDone.Do(func() {
+		f.done = make(chan struct{})
+	})
+
+	return f.done
+}
+
+// complete completes the Future with outcome, if it isn't already complete, and
+// returns a boolean indicating if it was completed by this call.
+func (f *Future[T]) complete(outcome outcome[T]) bool {
+	done := f.doneChannel()
+
+	if !f.outcome.SetIfUnset(outcome) {
+		return false
+	}
+
+	close(done)
+
+	return true
+}
+
+// Complete completes the Future with storeValue, and returns a boolean
+// indicating if it was completed by this call. Only the first call to Complete
+// or Fail completes the Future.
+func (f *Future[T]) Complete(storeValue T) bool {
+	return f.complete(outcome[T]{stored: storeValue})
+}
+
+// Fail completes the Future with err, and returns a boolean indicating if it
+// was completed by this call. Only the first call to Complete or Fail completes
+// the Future.
+func (f *Future[T]) Fail(err error) bool {
+	return f.complete(outcome[T]{err: err})
+}
+
+// Done returns a channel that is closed when the Future is completed.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.doneChannel()
+}
+
+// Await returns the value the Future was completed with, blocking until it is
+// completed, or the Context is cancelled, in which case a WaitError is
+// returned, like GetWait. If the Future failed, its error is returned.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-ctx.Done():
+		var zero T
+
+		return zero, waitError(ctx.Err())
+	case <-f.doneChannel():
+		outcome := f.outcome.Get()
+
+		return outcome.stored, outcome.err
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// ring is a fixed-capacity ring buffer, which discards its oldest items when
+// full. The zero value has no capacity, and discards all items.
+type ring[T any] struct {
+	items []T
+	start int
+	size  int
+}
+
+// newRing returns a ring with capacity for size items.
+func newRing[T any](size int) ring[T] {
+	return ring[T]{items: make([]T, size)}
+}
+
+// push adds item to the ring, discarding the oldest item if it is full. It
+// returns the discarded item, which is item itself if the ring has no
+// capacity, and a boolean indicating if an item was discarded.
+func (r *ring[T]) push(item T) (T, bool) {
+	if len(r.items) == 0 {
+		return item, true
+	}
+
+	if r.size < len(r.items) {
+		r.items[(r.start+r.size)%len(r.items)] = item
+		r.size++
+
+		var zero T
+
+		return zero, false
+	}
+
+	discarded := r.items[r.start]
+	r.items[r.start] = item
+	r.start = (r.start + 1) % len(r.items)
+
+	return discarded, true
+}
+
+// last returns the newest item in the ring, and a boolean indicating if the
+// ring has any items.
+func (r *ring[T]) last() (T, bool) {
+	if r.size == 0 {
+		var zero T
+
+		return zero, false
+	}
+
+	return r.items[(r.start+r.size-1)%len(r.items)], true
+}
+
+// slice returns a copy of the items in the ring, oldest first.
+func (r *ring[T]) slice() []T {
+	items := make([]T, r.size)
+	for i := range items {
+		items[i] = r.items[(r.start+i)%len(r.items)]
+	}
+
+	return items
+}
+
+// retainLocked adds e to the retained changes, recording the version of the
+// change it discards, if any. The lock must be held by the caller.
+func (v *Value[T]) retainLocked(e Event[T]) {
+	if discarded, ok := v.changes.push(e); ok {
+		v.discardedVersion = discarded.Version
+	}
+}
+
+// changesSinceLocked returns the retained changes made since version, oldest
+// first, or an EventGap followed by the current value if any of them are no
+// longer retained. The lock must be held by the caller.
+func (v *Value[T]) changesSinceLocked(version uint64) []Event[T] {
+	if version < v.discardedVersion || version > v.version {
+		changes := []Event[T]{{Kind: EventGap, Version: v.version, Time: v.clock().Now()}}
+		if v.set {
+			changes = append(changes, v.currentEventLocked())
+		}
+
+		return changes
+	}
+
+	var changes []Event[T]
+	for _, e := range v.changes.slice() {
+		if e.Version > version {
+			e.Old = v.copied(e.Old)
+			e.New = v.copied(e.New)
+			changes = append(changes, e)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// Getter is implemented by types that provide explicitly settable values, such
+// as Value and Optional. It permits APIs to accept read-only access to a value.
+type Getter[T any] interface {
+	// Get returns the stored value.
+	Get() T
+
+	// GetOk returns the stored value and a boolean indicating if the value
+	// was explicitly set.
+	GetOk() (T, bool)
+}
+
+// Setter is implemented by types whose values can be explicitly set, such as
+// Value. It permits APIs to accept write-only access to a value.
+type Setter[T any] interface {
+	// Set sets the value explicitly.
+	Set(T)
+}
+
+var (
+	_ Getter[any] = (*Value[any])(nil)
+	_ Setter[any] = (*Value[any])(nil)
+	_ Getter[any] = Optional[any]{}
+)
@@ -0,0 +1,73 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+var (
+	_ json.Marshaler   = (*Value[any])(nil)
+	_ json.Unmarshaler = (*Value[any])(nil)
+)
+
+// MarshalJSON encodes the stored value as JSON if it is explicitly set, and as
+// null otherwise, so an unset value isn't mistaken for its default or zero
+// value. It implements json.Marshaler. As it has a pointer receiver, a Value
+// field of a struct is only encoded this way if the struct is addressable, such
+// as when a pointer to it is encoded.
+func (v *Value[T]) MarshalJSON() ([]byte, error) {
+	stored, ok := v.GetOk()
+	if !ok {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(stored)
+}
+
+// UnmarshalJSON decodes data into the stored value, and sets the value
+// explicitly, so a field that is present in decoded JSON is explicitly set,
+// even to the zero value of its type. A field that is absent leaves the Value
+// unchanged, and null unsets it. If the Value can't be set, such as because it
+// is frozen or a validator rejected the value, the error is returned. It
+// implements json.Unmarshaler.
+func (v *Value[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return v.unsetErr()
+	}
+
+	var storeValue T
+	if err := json.Unmarshal(data, &storeValue); err != nil {
+		return err
+	}
+
+	return v.SetErr(storeValue)
+}
+
+// unsetErr is like Unset, but returns an error if the Value is frozen, rather
+// than panicking.
+func (v *Value[T]) unsetErr() error {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if err := v.mutableLocked(); err != nil {
+		return err
+	}
+
+	v.unsetLocked(EventUnset)
+
+	return nil
+}
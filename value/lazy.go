@@ -0,0 +1,67 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "sync"
+
+// Lazy is a Value that is explicitly set by a provider function, the first
+// time it is read.
+type Lazy[T any] struct {
+	once     sync.Once
+	provider func() T
+	value    Value[T]
+	// panicked indicates if the provider panicked, with panicValue.
+	panicked   bool
+	panicValue any
+}
+
+// NewLazy returns a new Lazy whose value is provided by provider.
+func NewLazy[T any](provider func() T) *Lazy[T] {
+	return &Lazy[T]{provider: provider}
+}
+
+// Value returns the underlying Value, which is explicitly set by the provider
+// if this is the first time Lazy is read. Concurrent callers block until the
+// provider returns, and the provider is only ever called once. If it panics,
+// every call panics with the same value, rather than returning a Value that
+// will never be set.
+func (l *Lazy[T]) Value() *Value[T] {
+	l.once.Do(func() {
+		provided := false
+		defer func() {
+			if !provided {
+				l.panicked = true
+				l.panicValue = recover()
+				panic(l.panicValue)
+			}
+		}()
+
+		l.value.Set(l.provider())
+		l.provider = nil
+		provided = true
+	})
+
+	if l.panicked {
+		panic(l.panicValue)
+	}
+
+	return &l.value
+}
+
+// Get returns the stored value, calling the provider if this is the first time
+// Lazy is read.
+func (l *Lazy[T]) Get() T {
+	return l.Value().Get()
+}
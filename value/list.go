@@ -0,0 +1,52 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// List is a Value of a slice type, with atomic appends.
+type List[T any] struct {
+	Value[[]T]
+}
+
+// Append appends items to a copy of the stored slice, and sets the value
+// explicitly. Slices previously returned by Get, and the slice the List was
+// created or set with, are not modified, even if they have spare capacity.
+//
+// Append holds the lock while appending and setting the value.
+func (l *List[T]) Append(items ...T) {
+	l.mu.Lock()
+	defer l.unlock()
+
+	stored := l.stored[:len(l.stored):len(l.stored)]
+	l.setLocked(l.mustPrepareLocked(append(stored, items...)))
+}
+
+// Len returns the length of the stored slice.
+func (l *List[T]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.stored)
+}
+
+// NewList returns a new List with its value explicitly set to items,
+// configured by opts.
+func NewList[T any](items []T, opts ...Option[[]T]) *List[T] {
+	var newList List[T]
+
+	newList.configure(opts)
+	newList.Set(items)
+
+	return &newList
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// Number is a Value of a numeric type, with atomic arithmetic. An unset Number
+// is treated as zero.
+type Number[T integer | float] struct {
+	Value[T]
+}
+
+// apply sets the value explicitly to the result of calling fn with the stored
+// value, and returns the new value.
+//
+// apply holds the lock while reading and setting the value.
+func (n *Number[T]) apply(fn func(T) T) T {
+	n.mu.Lock()
+	defer n.unlock()
+
+	n.setLocked(n.mustPrepareLocked(fn(n.stored)))
+
+	return n.stored
+}
+
+// Add adds delta to the value, and returns the new value.
+func (n *Number[T]) Add(delta T) T {
+	return n.apply(func(stored T) T {
+		return stored + delta
+	})
+}
+
+// Inc adds one to the value, and returns the new value.
+func (n *Number[T]) Inc() T {
+	return n.Add(1)
+}
+
+// Dec subtracts one from the value, and returns the new value.
+func (n *Number[T]) Dec() T {
+	return n.apply(func(stored T) T {
+		return stored - 1
+	})
+}
+
+// NewNumber returns a new Number with its value explicitly set, configured
+// by opts.
+func NewNumber[T integer | float](storeValue T, opts ...Option[T]) *Number[T] {
+	var newNumber Number[T]
+
+	newNumber.configure(opts)
+	newNumber.Set(storeValue)
+
+	return &newNumber
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// Optional is a lightweight alternative to Value, which distinguishes explicitly
+// set values from unset ones, but has no synchronization. It is not safe for
+// concurrent use, and can be copied. The zero value is unset.
+type Optional[T any] struct {
+	stored T
+	set    bool
+}
+
+// Some returns an Optional explicitly set to storeValue.
+func Some[T any](storeValue T) Optional[T] {
+	return Optional[T]{stored: storeValue, set: true}
+}
+
+// Set sets the Optional explicitly.
+func (o *Optional[T]) Set(storeValue T) {
+	o.stored = storeValue
+	o.set = true
+}
+
+// Unset returns the Optional to its unset state, storing the zero value of the
+// type.
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+// Get returns the stored value.
+func (o Optional[T]) Get() T {
+	return o.stored
+}
+
+// GetOk returns the stored value and a boolean indicating if the Optional was
+// explicitly set.
+func (o Optional[T]) GetOk() (T, bool) {
+	return o.stored, o.set
+}
+
+// GetOr returns the stored value if the Optional is explicitly set, otherwise
+// it returns defaultValue.
+func (o Optional[T]) GetOr(defaultValue T) T {
+	if !o.set {
+		return defaultValue
+	}
+
+	return o.stored
+}
+
+// IsSet returns a boolean indicating if the Optional was explicitly set.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Value returns a new Value with the same stored value and set state as the
+// Optional.
+func (o Optional[T]) Value() *Value[T] {
+	newValue := &Value[T]{stored: o.stored, set: o.set}
+	newValue.publishLocked()
+
+	return newValue
+}
+
+// Optional returns an Optional with the same stored value and set state as v.
+func (v *Value[T]) Optional() Optional[T] {
+	stored, ok := v.GetOk()
+
+	return Optional[T]{stored: stored, set: ok}
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures a Value when it is created. Options are accepted by NewWith,
+// New, and the constructors of the types built on Value, and are the extension
+// point for configurable behavior.
+type Option[T any] func(*options[T])
+
+// options holds the configuration of a Value.
+type options[T any] struct {
+	clock        Clock
+	reaper       *Reaper
+	historySize  int
+	defaultValue T
+	ttl          time.Duration
+	normalizers  []func(T) T
+	validators   []func(T) error
+	equal        func(a, b T) bool
+	copy         func(T) T
+	interceptors []func(e Event[T], next func(Event[T]))
+	wakePolicy   WakePolicy
+}
+
+// WakePolicy determines which waiters are woken when a Value is explicitly set,
+// as configured with WithWakePolicy.
+type WakePolicy int
+
+const (
+	// WakeAll wakes every waiter, each of which returns the same value. It
+	// suits broadcasting a value, and is the default.
+	WakeAll WakePolicy = iota
+
+	// WakeOne wakes only the waiter that has waited longest, so each set is
+	// handled by a single waiter. It suits distributing work among waiters.
+	// A waiter whose Context is cancelled no longer waits, and doesn't
+	// consume a set.
+	WakeOne
+)
+
+// WithValidator configures a Value to call validate with each value it is about
+// to be set to. If validate returns an error, the value isn't set, and waiters
+// aren't woken. Methods that set the value return the error if they return an
+// error, and panic otherwise. If more than one validator is configured, they
+// are called in order, until one returns an error.
+func WithValidator[T any](validate func(T) error) Option[T] {
+	return func(o *options[T]) {
+		o.validators = append(o.validators, validate)
+	}
+}
+
+// WithNonZero configures a Value to reject being explicitly set to the zero
+// value of its type, with ErrZero.
+func WithNonZero[T comparable]() Option[T] {
+	return WithValidator(func(storeValue T) error {
+		var zero T
+		if storeValue == zero {
+			return fmt.Errorf("%s: %w", typeName[T](), ErrZero)
+		}
+
+		return nil
+	})
+}
+
+// WithNormalizer configures a Value to store the result of calling normalize
+// with each value it is set to, such as to trim strings or canonicalize paths.
+// Normalizers are called in order, before any validators.
+func WithNormalizer[T any](normalize func(T) T) Option[T] {
+	return func(o *options[T]) {
+		o.normalizers = append(o.normalizers, normalize)
+	}
+}
+
+// WithEqual configures a Value to use equal to determine if a new value is the
+// same as the stored value, such as for slices, maps, or structs containing
+// them. Setting a Value to an equal value is a no-op, which doesn't wake
+// waiters, notify watchers, or increment the version, and SetChanged reports
+// it as unchanged.
+func WithEqual[T any](equal func(a, b T) bool) Option[T] {
+	return func(o *options[T]) {
+		o.equal = equal
+	}
+}
+
+// WithCopy configures a Value to store a copy of each value it is set to, and to
+// return a copy of the stored value from reads, using copy. This prevents
+// callers from modifying a stored map, slice, or pointed-to value without
+// setting the Value. Functions passed to Update and UpdateErr also receive a
+// copy.
+func WithCopy[T any](copy func(T) T) Option[T] {
+	return func(o *options[T]) {
+		o.copy = copy
+	}
+}
+
+// WithInterceptor configures a Value to pass each Event through intercept
+// before it is delivered. intercept delivers the Event by calling next, which it
+// may do with a modified Event, or not at all, to filter it. This permits adding
+// logging, metrics, or filtering without wrapping the Value. Interceptors are
+// called in the order they were configured, in the goroutine delivering the
+// Event, once per change, whether or not any callbacks are registered.
+//
+// A change wakes waiters, such as those blocked in GetWait, WaitSet, or
+// WaitChangedSince, or on the channel returned by Changed, and updates Values
+// created by Derive, only once the last interceptor calls next, so a filtered
+// change wakes nothing. Waits ending with an error, such as by Reset, aren't
+// intercepted. The retained changes delivered by SubscribeSince, and the
+// current value delivered to callbacks configured with WithReplayCurrent, don't
+// pass through interceptors.
+func WithInterceptor[T any](intercept func(e Event[T], next func(Event[T]))) Option[T] {
+	return func(o *options[T]) {
+		o.interceptors = append(o.interceptors, intercept)
+	}
+}
+
+// WithWakePolicy configures a Value to wake waiters blocked in GetWait, and the
+// methods built on it, according to policy when it is explicitly set. Waiters
+// are always all woken when a wait ends with an error, such as by Reset,
+// SetFailed, or expiring.
+func WithWakePolicy[T any](policy WakePolicy) Option[T] {
+	return func(o *options[T]) {
+		o.wakePolicy = policy
+	}
+}
+
+// WithDefault configures a Value to store defaultValue while it is unset. Get
+// returns the default value, but GetOk and IsSet still report that the value
+// was not explicitly set.
+func WithDefault[T any](defaultValue T) Option[T] {
+	return func(o *options[T]) {
+		o.defaultValue = defaultValue
+	}
+}
+
+// WithClock configures a Value to use clock as its source of time, instead of
+// the system clock.
+func WithClock[T any](clock Clock) Option[T] {
+	return func(o *options[T]) {
+		o.clock = clock
+	}
+}
+
+// WithReaper configures a Value to use reaper to expire values set with a TTL.
+// Values configured with WithClock, but not WithReaper, have their own Reaper
+// that uses the configured Clock. Otherwise a default Reaper that uses the
+// system clock is shared by all Values.
+func WithReaper[T any](reaper *Reaper) Option[T] {
+	return func(o *options[T]) {
+		o.reaper = reaper
+	}
+}
+
+// WithTTL configures a Value so that each time it is explicitly set, it expires
+// after ttl, as if set with SetWithTTL.
+func WithTTL[T any](ttl time.Duration) Option[T] {
+	return func(o *options[T]) {
+		o.ttl = ttl
+	}
+}
+
+// WithHistory configures a Value to retain up to size of its previously stored
+// values, which are available from Previous and History, and its last size
+// changes, which are delivered by SubscribeSince.
+func WithHistory[T any](size int) Option[T] {
+	return func(o *options[T]) {
+		o.historySize = size
+	}
+}
+
+// NewWith returns a new unset Value, configured by opts.
+func NewWith[T any](opts ...Option[T]) *Value[T] {
+	var newValue Value[T]
+
+	newValue.configure(opts)
+
+	return &newValue
+}
+
+// configure applies opts to a newly created Value, before it is used.
+func (v *Value[T]) configure(opts []Option[T]) {
+	for _, opt := range opts {
+		opt(&v.opts)
+	}
+
+	if v.opts.clock != nil && v.opts.reaper == nil {
+		v.opts.reaper = NewReaper(v.opts.clock)
+	}
+
+	v.history = newRing[T](v.opts.historySize)
+	v.changes = newRing[Event[T]](v.opts.historySize)
+	v.stored = v.opts.defaultValue
+	v.publishLocked()
+}
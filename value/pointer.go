@@ -0,0 +1,38 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+// FromPtr returns a new Value configured by opts, which is explicitly set to
+// the value p points to, or unset if p is nil.
+func FromPtr[T any](p *T, opts ...Option[T]) *Value[T] {
+	newValue := NewWith(opts...)
+
+	if p != nil {
+		newValue.Set(*p)
+	}
+
+	return newValue
+}
+
+// Ptr returns a pointer to a copy of the stored value, or nil if the value is
+// not explicitly set.
+func (v *Value[T]) Ptr() *T {
+	stored, ok := v.GetOk()
+	if !ok {
+		return nil
+	}
+
+	return &stored
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Reaper expires values set with a TTL. A single Reaper serves any number of
+// Values, scheduling only one function with its Clock at a time, for the
+// earliest pending expiration.
+type Reaper struct {
+	clock Clock
+
+	mu      sync.Mutex
+	pending expirationHeap
+	timer   Timer
+	timerAt time.Time
+}
+
+// defaultReaper is used by Values not configured with a Clock or Reaper.
+var defaultReaper = NewReaper(systemClock{})
+
+// NewReaper returns a new Reaper that uses clock to determine when expirations
+// are due.
+func NewReaper(clock Clock) *Reaper {
+	return &Reaper{clock: clock}
+}
+
+// expiration is a function scheduled to be called by a Reaper.
+type expiration struct {
+	at    time.Time
+	fn    func()
+	index int
+}
+
+// expirationHeap is a min-heap of expirations, ordered by their time. It
+// implements heap.Interface.
+type expirationHeap []*expiration
+
+func (h expirationHeap) Len() int {
+	return len(h)
+}
+
+func (h expirationHeap) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at)
+}
+
+func (h expirationHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expirationHeap) Push(x any) {
+	e := x.(*expiration)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *expirationHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*h = old[:len(old)-1]
+
+	return e
+}
+
+// schedule arranges for fn to be called after ttl, and returns a function that
+// cancels it.
+//
+// schedule acquires the lock from start to finish.
+func (r *Reaper) schedule(ttl time.Duration, fn func()) (cancel func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &expiration{at: r.clock.Now().Add(ttl), fn: fn}
+	heap.Push(&r.pending, e)
+	r.armLocked()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if e.index >= 0 {
+			heap.Remove(&r.pending, e.index)
+		}
+	}
+}
+
+// armLocked ensures a function is scheduled with the Clock for the earliest
+// pending expiration. The lock must be held by the caller.
+func (r *Reaper) armLocked() {
+	if len(r.pending) == 0 {
+		return
+	}
+
+	next := r.pending[0].at
+	if r.timer != nil && !r.timerAt.After(next) {
+		return
+	}
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	r.timerAt = next
+	r.timer = r.clock.AfterFunc(next.Sub(r.clock.Now()), r.reap)
+}
+
+// reap calls the functions of all expirations that are due, then schedules the
+// next pending expiration. The functions are called without holding the lock.
+func (r *Reaper) reap() {
+	r.mu.Lock()
+	r.timer = nil
+
+	now := r.clock.Now()
+	var due []*expiration
+	for len(r.pending) > 0 && !r.pending[0].at.After(now) {
+		due = append(due, heap.Pop(&r.pending).(*expiration))
+	}
+
+	r.armLocked()
+	r.mu.Unlock()
+
+	for _, e := range due {
+		e.fn()
+	}
+}
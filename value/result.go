@@ -0,0 +1,81 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"fmt"
+)
+
+// outcome is either a value, or the error that prevented producing it.
+type outcome[T any] struct {
+	stored T
+	err    error
+}
+
+// Result is a Value that is explicitly set to either a value or an error, such
+// as the outcome of loading configuration asynchronously.
+type Result[T any] struct {
+	outcome Value[outcome[T]]
+}
+
+// Set sets the Result explicitly to storeValue.
+func (r *Result[T]) Set(storeValue T) {
+	r.outcome.Set(outcome[T]{stored: storeValue})
+}
+
+// SetErr sets the Result explicitly to err.
+func (r *Result[T]) SetErr(err error) {
+	r.outcome.Set(outcome[T]{err: err})
+}
+
+// Get returns the stored value or error. If the Result was not explicitly set,
+// an error wrapping ErrNotSet is returned, like Value.GetErr.
+func (r *Result[T]) Get() (T, error) {
+	stored, ok := r.outcome.GetOk()
+	if !ok {
+		return stored.stored, fmt.Errorf("%s: %w", typeName[T](), ErrNotSet)
+	}
+
+	return stored.stored, stored.err
+}
+
+// IsSet returns a boolean indicating if the Result was explicitly set.
+func (r *Result[T]) IsSet() bool {
+	return r.outcome.IsSet()
+}
+
+// GetWait returns the stored value or error, but blocks until the Result is
+// next explicitly set, or the Context is cancelled, like Value.GetWait.
+func (r *Result[T]) GetWait(ctx context.Context) (T, error) {
+	stored, err := r.outcome.GetWait(ctx)
+	if err != nil {
+		return stored.stored, err
+	}
+
+	return stored.stored, stored.err
+}
+
+// GetWaitTrigger is like GetWait, but calls trigger once the caller is
+// registered as a waiter, so a set made by trigger is never missed, like
+// Value.GetWaitTrigger.
+func (r *Result[T]) GetWaitTrigger(ctx context.Context, trigger func()) (T, error) {
+	stored, err := r.outcome.GetWaitTrigger(ctx, trigger)
+	if err != nil {
+		return stored.stored, err
+	}
+
+	return stored.stored, stored.err
+}
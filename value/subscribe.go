@@ -0,0 +1,689 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy determines how a Subscription created with WithBuffer handles
+// a value being set while its buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block causes setting the Value to block until the Subscription has
+	// room for the value, so every value is delivered, at the pace of the
+	// receiver. Callbacks of a Value with Subscriptions using Block must not
+	// set the Value, as they would wait for their own delivery. TrySet doesn't
+	// set such a Value, and SetContext stops waiting once its Context is done.
+	Block OverflowPolicy = iota
+
+	// DropOldest causes the oldest buffered value to be discarded to make room
+	// for the new value.
+	DropOldest
+
+	// KeepLatest causes every buffered value to be discarded, so only the new
+	// value remains.
+	KeepLatest
+)
+
+// Delivery determines the delivery guarantee of a Subscription configured with
+// WithDelivery.
+type Delivery int
+
+const (
+	// Queued delivers every value, queuing as many as necessary, so setting
+	// the Value never blocks. It is the default.
+	Queued Delivery = iota
+
+	// EveryValue delivers every value, blocking setting the Value until the
+	// previous value has been received. It is equivalent to WithBuffer with a
+	// size of 0 and Block.
+	EveryValue
+
+	// LatestOnly delivers only the latest value, never blocking setting the
+	// Value. Values set while the previous one hasn't been received are lost.
+	// It is equivalent to WithBuffer with a size of 1 and KeepLatest.
+	LatestOnly
+)
+
+// SubscribeOption configures a Subscription.
+type SubscribeOption[T any] func(*subscribeOptions[T])
+
+// subscribeOptions holds the configuration of a Subscription.
+type subscribeOptions[T any] struct {
+	bounded bool
+	buffer  int
+	policy  OverflowPolicy
+	current bool
+	filter  func(T) bool
+	// slow is called when the Subscription discards values, or blocks
+	// delivery for longer than slowAfter.
+	slow      func(s *Subscription[T], err error)
+	slowAfter time.Duration
+}
+
+// WithBuffer configures a Subscription to buffer at most size values that
+// haven't been received, handling more according to policy. Without WithBuffer,
+// every value is buffered until it is received. DropOldest and KeepLatest
+// buffer at least one value, and never block setting the Value. A receiver of a
+// Subscription using Block may hold one value in addition to those buffered,
+// so a size of 0 blocks setting the Value until the previous value has been
+// received.
+func WithBuffer[T any](size int, policy OverflowPolicy) SubscribeOption[T] {
+	return func(o *subscribeOptions[T]) {
+		o.bounded = true
+		o.buffer = size
+		o.policy = policy
+	}
+}
+
+// WithDelivery configures a Subscription to provide delivery. It overrides
+// WithBuffer, and is overridden by it, whichever is last.
+func WithDelivery[T any](delivery Delivery) SubscribeOption[T] {
+	return func(o *subscribeOptions[T]) {
+		switch delivery {
+		case EveryValue:
+			WithBuffer[T](0, Block)(o)
+		case LatestOnly:
+			WithBuffer[T](1, KeepLatest)(o)
+		default:
+			o.bounded = false
+		}
+	}
+}
+
+// WithSlowConsumer configures a Subscription to call report when its receiver
+// falls behind: each time values are discarded by DropOldest or KeepLatest, and
+// each time delivery is blocked by Block for longer than threshold, according
+// to the Clock of the Value. The error passed to report wraps ErrSlowConsumer,
+// and describes how it fell behind. report is called without holding any
+// locks, so it may close the Subscription.
+func WithSlowConsumer[T any](threshold time.Duration, report func(s *Subscription[T], err error)) SubscribeOption[T] {
+	return func(o *subscribeOptions[T]) {
+		o.slow = report
+		o.slowAfter = threshold
+	}
+}
+
+// WithCurrent configures a Subscription to first deliver the current value, if
+// the Value is explicitly set, so it doesn't wait for the next set to learn of
+// it.
+func WithCurrent[T any]() SubscribeOption[T] {
+	return func(o *subscribeOptions[T]) {
+		o.current = true
+	}
+}
+
+// WithFilter configures a Subscription to only deliver the values that filter
+// returns true for, including the current value delivered by WithCurrent.
+// Other values are discarded before they are buffered, so they never wake the
+// receiver, or block setting the Value. filter is called by the goroutine that
+// changed the Value, and must only depend on the value it is called with.
+func WithFilter[T any](filter func(T) bool) SubscribeOption[T] {
+	return func(o *subscribeOptions[T]) {
+		o.filter = filter
+	}
+}
+
+// Subscription delivers the values a Value is set to, in order, on its channel,
+// until it ends. It ends when its Context is done, when it is closed, or when
+// the Value is frozen, and Err reports which.
+type Subscription[T any] struct {
+	mu    sync.Mutex
+	opts  subscribeOptions[T]
+	clock Clock
+	queue []T
+	// dropped is the number of values discarded by DropOldest or KeepLatest.
+	dropped uint64
+	// room is signalled when values are removed from queue, or the
+	// Subscription ends.
+	room sync.Cond
+	// ended indicates that no more values will be queued, and err holds why.
+	ended bool
+	err   error
+	// paused indicates that values are not delivered. held holds the values
+	// set while paused, for Subscriptions using DropOldest or KeepLatest.
+	paused bool
+	held   []T
+	// pausing is signalled by Pause, so run stops delivering a value.
+	pausing chan struct{}
+	// ready is signalled when queue becomes non-empty, the Subscription is
+	// resumed, or it ends.
+	ready chan struct{}
+	// stop is closed by Close, and finished after c is closed.
+	stop     chan struct{}
+	stopOnce sync.Once
+	finished chan struct{}
+	c        chan T
+	// closed indicates that c is closed, or about to be, so nothing may be
+	// sent on it.
+	closed bool
+}
+
+// newSubscription returns a new Subscription configured by opts, which uses
+// clock to measure how long delivery is blocked.
+func newSubscription[T any](clock Clock, opts []SubscribeOption[T]) *Subscription[T] {
+	s := &Subscription[T]{
+		clock:    clock,
+		pausing:  make(chan struct{}, 1),
+		ready:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	s.room.L = &s.mu
+
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+
+	if s.direct() {
+		if s.opts.buffer < 1 {
+			s.opts.buffer = 1
+		}
+		s.c = make(chan T, s.opts.buffer)
+	} else {
+		s.c = make(chan T)
+	}
+
+	return s
+}
+
+// direct indicates if values are sent directly to the buffered channel, rather
+// than queued for run to deliver, because they are never blocked on.
+func (s *Subscription[T]) direct() bool {
+	return s.opts.bounded && s.opts.policy != Block
+}
+
+// blocks indicates if the Subscription uses Block, so setting the Value waits
+// for delivery to it.
+func (s *Subscription[T]) blocks() bool {
+	return s.opts.bounded && s.opts.policy == Block
+}
+
+// accepts indicates if the Subscription delivers e, according to its filter.
+func (s *Subscription[T]) accepts(e T) bool {
+	return s.opts.filter == nil || s.opts.filter(e)
+}
+
+// signal wakes run, if it is waiting for values.
+func (s *Subscription[T]) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// deliver queues storeValue for delivery, reports values it discards, and
+// blocks until there is room for it, if the Subscription uses Block.
+func (s *Subscription[T]) deliver(storeValue T) {
+	full, dropped := s.push(storeValue)
+	if dropped > 0 {
+		s.reportSlow(fmt.Errorf("%w: buffer full, discarded %d", ErrSlowConsumer, dropped))
+	}
+
+	if full {
+		s.waitForRoom()
+	}
+}
+
+// reportSlow calls the function configured with WithSlowConsumer, if any.
+func (s *Subscription[T]) reportSlow(err error) {
+	if s.opts.slow != nil {
+		s.opts.slow(s, err)
+	}
+}
+
+// push queues storeValue for delivery. It never blocks, so it may be called
+// while holding the lock of the Value. It returns a boolean indicating if the
+// Subscription uses Block and doesn't have room for storeValue, in which case
+// waitForRoom must be called to block delivery of further values, and the
+// number of values discarded to make room for it.
+func (s *Subscription[T]) push(storeValue T) (full bool, dropped int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return false, 0
+	}
+
+	if s.direct() {
+		if s.paused {
+			dropped = s.holdLocked(storeValue)
+		} else {
+			dropped = s.offerLocked(storeValue)
+		}
+		s.dropped += uint64(dropped)
+
+		return false, dropped
+	}
+
+	s.queue = append(s.queue, storeValue)
+	s.signal()
+
+	return s.opts.bounded && len(s.queue) > s.opts.buffer, 0
+}
+
+// offerLocked sends storeValue to the buffered channel, discarding buffered
+// values according to the OverflowPolicy until there is room for it, and
+// returns the number of values discarded. The lock of the Subscription must be
+// held by the caller.
+func (s *Subscription[T]) offerLocked(storeValue T) (dropped int) {
+	for {
+		select {
+		case s.c <- storeValue:
+			return dropped
+		default:
+		}
+
+	discard:
+		for {
+			select {
+			case <-s.c:
+				dropped++
+				if s.opts.policy == DropOldest {
+					break discard
+				}
+			default:
+				break discard
+			}
+		}
+	}
+}
+
+// holdLocked holds storeValue until the Subscription is resumed, discarding
+// held values according to the OverflowPolicy while more than its buffer size
+// are held, and returns the number of values discarded. The lock of the
+// Subscription must be held by the caller.
+func (s *Subscription[T]) holdLocked(storeValue T) (dropped int) {
+	s.held = append(s.held, storeValue)
+
+	if len(s.held) > s.opts.buffer {
+		keep := s.opts.buffer
+		if s.opts.policy == KeepLatest {
+			keep = 1
+		}
+
+		dropped = len(s.held) - keep
+		s.held = append([]T(nil), s.held[dropped:]...)
+	}
+
+	return dropped
+}
+
+// waitForRoom blocks until the queue is no longer over its buffer size, or the
+// Subscription ends.
+func (s *Subscription[T]) waitForRoom() {
+	if s.opts.slow != nil {
+		threshold := s.opts.slowAfter
+		timer := s.clock.AfterFunc(threshold, func() {
+			s.reportSlow(fmt.Errorf("%w: blocked for more than %s", ErrSlowConsumer, threshold))
+		})
+		defer timer.Stop()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.ended && len(s.queue) > s.opts.buffer {
+		s.room.Wait()
+	}
+}
+
+// end records err as the reason the Subscription ended, unless it has already
+// ended. Values that are already queued are still delivered.
+func (s *Subscription[T]) end(err error) {
+	s.mu.Lock()
+	if !s.ended {
+		s.ended = true
+		s.err = err
+	}
+	s.room.Broadcast()
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+// pop removes and returns the oldest queued value, and a boolean indicating if
+// there was one. If there wasn't, it also returns a boolean indicating if the
+// Subscription has ended, so there never will be. Nothing is removed while the
+// Subscription is paused.
+func (s *Subscription[T]) pop() (next T, ok bool, ended bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return next, false, s.ended
+	}
+
+	if s.paused {
+		return next, false, false
+	}
+
+	next = s.queue[0]
+	s.queue = s.queue[1:]
+	s.room.Broadcast()
+
+	return next, true, false
+}
+
+// unpop returns next to the front of the queue, as it wasn't delivered.
+func (s *Subscription[T]) unpop(next T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = append([]T{next}, s.queue...)
+}
+
+// run delivers queued values on the channel until the Subscription ends and
+// its queue is empty, ctx is done, or it is closed. It returns a boolean
+// indicating if values remaining in the channel must be discarded, because it
+// didn't end by running out of values.
+func (s *Subscription[T]) run(ctx context.Context) (discard bool) {
+	for {
+		var next T
+		ok, ended := false, false
+		if !s.direct() {
+			next, ok, ended = s.pop()
+		} else {
+			ended = s.isEnded()
+		}
+
+		if !ok {
+			if ended {
+				// values buffered by a closed Subscription are discarded
+				select {
+				case <-s.stop:
+					return true
+				default:
+					return false
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				s.end(ctx.Err())
+				return true
+			case <-s.stop:
+				return true
+			case <-s.ready:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			s.end(ctx.Err())
+			return true
+		case <-s.stop:
+			return true
+		case <-s.pausing:
+			s.unpop(next)
+		case s.c <- next:
+		}
+	}
+}
+
+// isEnded returns a boolean indicating if the Subscription has ended, and has
+// no held values left to deliver.
+func (s *Subscription[T]) isEnded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ended && len(s.held) == 0
+}
+
+// Pause stops the delivery of values until Resume is called. Values set while
+// paused are kept for delivery once resumed, subject to the buffer size and
+// OverflowPolicy configured with WithBuffer, so a Subscription using KeepLatest
+// delivers only the latest value set while paused. This permits suspending
+// reactions during bulk changes, and then processing the final state once. A
+// Subscription using Block blocks setting the Value while paused, once its
+// buffer is full. A value that is being received as Pause is called may still
+// be received. Pause has no effect once the Subscription has ended.
+func (s *Subscription[T]) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.paused || s.ended {
+		return
+	}
+	s.paused = true
+
+	if s.direct() {
+		// values already buffered in the channel are held instead
+		var buffered []T
+	drain:
+		for {
+			select {
+			case storeValue := <-s.c:
+				buffered = append(buffered, storeValue)
+			default:
+				break drain
+			}
+		}
+		s.held = append(buffered, s.held...)
+
+		return
+	}
+
+	select {
+	case s.pausing <- struct{}{}:
+	default:
+	}
+}
+
+// Resume resumes the delivery of values stopped by Pause, starting with those
+// set while paused. Values held by a Subscription that was closed, or whose
+// Context is done, are discarded.
+func (s *Subscription[T]) Resume() {
+	s.mu.Lock()
+	if s.paused {
+		s.paused = false
+
+		// values held by a Subscription that was closed are discarded
+		if !s.closed {
+			for _, storeValue := range s.held {
+				s.dropped += uint64(s.offerLocked(storeValue))
+			}
+		}
+		s.held = nil
+	}
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+// C returns the channel on which values are delivered. It is closed when the
+// Subscription ends.
+func (s *Subscription[T]) C() <-chan T {
+	return s.c
+}
+
+// Close ends the Subscription, discarding any values that haven't been
+// delivered. Err returns ErrClosed, unless it had already ended for another
+// reason. Close waits for the channel to be closed, so no value is received
+// from it after Close returns.
+func (s *Subscription[T]) Close() {
+	s.end(ErrClosed)
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	<-s.finished
+}
+
+// Dropped returns the number of values the Subscription has discarded because
+// its buffer was full, using DropOldest or KeepLatest.
+func (s *Subscription[T]) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped
+}
+
+// Err returns the reason the Subscription ended, or nil if it hasn't. It is
+// the error of its Context if that is done, ErrFrozen if the Value was frozen,
+// or ErrClosed if it was closed.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// subscriber is a Subscription of any type, which can be ended by the Value
+// it is registered with.
+type subscriber interface {
+	end(err error)
+}
+
+// NewSubscription returns a new Subscription, configured by opts, on which
+// every value v is subsequently set to is delivered, in order, until ctx is
+// done, the Subscription is closed, or v is frozen. Unless configured with
+// WithBuffer, values are queued for the Subscription, so setting v never blocks
+// on a slow receiver, and none are missed.
+func (v *Value[T]) NewSubscription(ctx context.Context, opts ...SubscribeOption[T]) *Subscription[T] {
+	return subscribe(ctx, v, opts, func(e Event[T]) (T, bool) {
+		return e.New, e.Kind == EventSet
+	}, func(current bool) []T {
+		if !current || !v.set {
+			return nil
+		}
+
+		return []T{v.copied(v.stored)}
+	})
+}
+
+// NewEventSubscription is like NewSubscription, but delivers an Event for each
+// change of v, whether by setting, unsetting, or expiring. If configured with
+// WithCurrent, and v is explicitly set, the first Event describes the current
+// value, as though it was just set.
+func (v *Value[T]) NewEventSubscription(ctx context.Context, opts ...SubscribeOption[Event[T]]) *Subscription[Event[T]] {
+	return subscribe(ctx, v, opts, func(e Event[T]) (Event[T], bool) {
+		return e, true
+	}, func(current bool) []Event[T] {
+		if !current || !v.set {
+			return nil
+		}
+
+		return []Event[T]{v.currentEventLocked()}
+	})
+}
+
+// SubscribeSince is like NewEventSubscription, but first delivers an Event for
+// each change of v made since version, such as the Version of the last Event a
+// consumer processed before it restarted, so none are missed. Changes are only
+// retained by Values configured with WithHistory, which retain as many changes
+// as values. If changes made since version are no longer retained, or v hasn't
+// reached version, an Event of kind EventGap is delivered instead, followed by
+// an Event describing the current value, as though it was just set, if v is
+// explicitly set. Retained changes are delivered as they were made, without
+// passing through interceptors. WithCurrent has no effect.
+func (v *Value[T]) SubscribeSince(ctx context.Context, version uint64, opts ...SubscribeOption[Event[T]]) *Subscription[Event[T]] {
+	return subscribe(ctx, v, opts, func(e Event[T]) (Event[T], bool) {
+		return e, true
+	}, func(bool) []Event[T] {
+		return v.changesSinceLocked(version)
+	})
+}
+
+// subscribe returns a new Subscription to v, configured by opts, which
+// delivers the result of pick for each Event that pick accepts. The results of
+// initialLocked, which is called with a boolean indicating if the Subscription
+// was configured with WithCurrent, while holding the lock of v, are delivered
+// first.
+func subscribe[T, E any](ctx context.Context, v *Value[T], opts []SubscribeOption[E], pick func(Event[T]) (E, bool), initialLocked func(current bool) []E) *Subscription[E] {
+	s := newSubscription(v.clock(), opts)
+
+	v.mu.Lock()
+	for _, initial := range initialLocked(s.opts.current) {
+		if s.accepts(initial) {
+			s.push(initial)
+		}
+	}
+	if v.frozen {
+		s.end(ErrFrozen)
+	}
+	if v.subscribers == nil {
+		v.subscribers = map[uint64]subscriber{}
+	}
+	v.lastSubscriber++
+	id := v.lastSubscriber
+	v.subscribers[id] = s
+	if s.blocks() {
+		v.blocking++
+	}
+	handlerID := v.addHandlerLocked(func(e Event[T]) {
+		if picked, ok := pick(e); ok && s.accepts(picked) {
+			s.deliver(picked)
+		}
+	})
+	v.mu.Unlock()
+
+	go func() {
+		defer close(s.finished)
+
+		// run only returns once s has ended, so push no longer sends to the
+		// channel
+		discard := s.run(ctx)
+
+		v.mu.Lock()
+		delete(v.subscribers, id)
+		if s.blocks() {
+			v.blocking--
+		}
+		v.removeHandlerLocked(handlerID)
+		v.mu.Unlock()
+
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+
+		close(s.c)
+		if discard {
+			for range s.c {
+			}
+		}
+	}()
+
+	return s
+}
+
+// Subscribe returns a channel on which every value v is subsequently set to is
+// delivered, in order, until ctx is done or v is frozen, after which the
+// channel is closed. It is configured by opts, like NewSubscription. Use
+// NewSubscription to close it explicitly, or learn why it was closed.
+func (v *Value[T]) Subscribe(ctx context.Context, opts ...SubscribeOption[T]) <-chan T {
+	return v.NewSubscription(ctx, opts...).C()
+}
+
+// endSubscriptionsLocked ends every Subscription with err, once the values
+// already set have been delivered to it. The lock must be held by the caller.
+func (v *Value[T]) endSubscriptionsLocked(err error) {
+	subscriptions := make([]subscriber, 0, len(v.subscribers))
+	for _, s := range v.subscribers {
+		subscriptions = append(subscriptions, s)
+	}
+
+	v.notifyQueue = append(v.notifyQueue, func() {
+		for _, s := range subscriptions {
+			s.end(err)
+		}
+	})
+}
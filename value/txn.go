@@ -0,0 +1,196 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// Txn stages writes to, and reads of, several Values, and commits them
+// atomically. While a Txn is being committed it holds the lock of every Value
+// it involves, so no reader observes a partially committed Txn, and the reads
+// it stages all observe the same state. The zero value is an empty Txn.
+type Txn struct {
+	ops []txnOp
+}
+
+// txnTarget is a Value that can be involved in a Txn.
+type txnTarget interface {
+	txnID() uint64
+	lockTxn()
+	unlockTxn() (notify func())
+}
+
+// txnOp is a staged operation of a Txn.
+type txnOp interface {
+	target() txnTarget
+
+	// prepare is called with the target's lock held, and returns an error if
+	// the operation can't be applied.
+	prepare() error
+
+	// apply is called with the target's lock held, after every operation of
+	// the Txn was prepared.
+	apply()
+}
+
+// lastTxnID is the most recently assigned Txn ID.
+var lastTxnID uint64
+
+// txnID returns an ID that uniquely identifies the Value, which is used to
+// acquire the locks of Values in a consistent order, to prevent deadlocks.
+func (v *Value[T]) txnID() uint64 {
+	if id := atomic.LoadUint64(&v.id); id != 0 {
+		return id
+	}
+
+	atomic.CompareAndSwapUint64(&v.id, 0, atomic.AddUint64(&lastTxnID, 1))
+
+	return atomic.LoadUint64(&v.id)
+}
+
+// lockTxn acquires the lock for a Txn.
+func (v *Value[T]) lockTxn() {
+	v.mu.Lock()
+}
+
+// unlockTxn releases the lock acquired by lockTxn, and returns a function that
+// must be called to notify listeners of any changes, and wait for them to be
+// delivered to Subscriptions that use Block, or nil. Listeners are only
+// notified once the locks of all Values involved in the Txn are released.
+func (v *Value[T]) unlockTxn() (notify func()) {
+	delivered := v.delivered
+	v.delivered = nil
+
+	notifying := v.unlockQueued()
+	if !notifying && len(delivered) == 0 {
+		return nil
+	}
+
+	return func() {
+		if notifying {
+			v.notifyListeners()
+		}
+
+		waitDelivered(delivered)
+	}
+}
+
+// txnSet is a staged write of a Txn.
+type txnSet[T any] struct {
+	v          *Value[T]
+	storeValue T
+}
+
+func (op *txnSet[T]) target() txnTarget {
+	return op.v
+}
+
+func (op *txnSet[T]) prepare() error {
+	storeValue, err := op.v.prepareLocked(op.storeValue)
+	op.storeValue = storeValue
+
+	return err
+}
+
+func (op *txnSet[T]) apply() {
+	op.v.setLocked(op.storeValue)
+}
+
+// txnGet is a staged read of a Txn.
+type txnGet[T any] struct {
+	v   *Value[T]
+	got *Optional[T]
+}
+
+func (op *txnGet[T]) target() txnTarget {
+	return op.v
+}
+
+func (op *txnGet[T]) prepare() error {
+	return nil
+}
+
+func (op *txnGet[T]) apply() {
+	*op.got = Optional[T]{stored: op.v.copied(op.v.stored), set: op.v.set}
+}
+
+// TxnSet stages explicitly setting v to storeValue when txn is committed.
+func TxnSet[T any](txn *Txn, v *Value[T], storeValue T) {
+	txn.ops = append(txn.ops, &txnSet[T]{v: v, storeValue: storeValue})
+}
+
+// TxnGet stages reading v when txn is committed, and returns the Optional that
+// the stored value and set state are read into. Operations are applied in the
+// order they were staged, so a read staged after a write of the same Value
+// observes the write.
+func TxnGet[T any](txn *Txn, v *Value[T]) *Optional[T] {
+	got := &Optional[T]{}
+	txn.ops = append(txn.ops, &txnGet[T]{v: v, got: got})
+
+	return got
+}
+
+// Commit applies the staged operations of txn atomically, and clears them. If
+// any staged write can't be applied, such as because a Value is frozen or a
+// validator rejected the value, none of the operations are applied, and the
+// error is returned.
+func (txn *Txn) Commit() error {
+	ops := txn.ops
+	txn.ops = nil
+
+	targets := map[uint64]txnTarget{}
+	for _, op := range ops {
+		target := op.target()
+		targets[target.txnID()] = target
+	}
+
+	ids := make([]uint64, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		targets[id].lockTxn()
+	}
+	defer func() {
+		var notifies []func()
+		for _, id := range ids {
+			if notify := targets[id].unlockTxn(); notify != nil {
+				notifies = append(notifies, notify)
+			}
+		}
+
+		for _, notify := range notifies {
+			notify()
+		}
+	}()
+
+	for _, op := range ops {
+		if err := op.prepare(); err != nil {
+			return err
+		}
+	}
+
+	for _, op := range ops {
+		op.apply()
+	}
+
+	return nil
+}
@@ -17,121 +17,1029 @@ package value
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Value is a generic type that represents explicitly settable values.
 type Value[T any] struct {
-	stored  T
-	set     bool
-	mu      sync.Mutex
-	waiting chan bool
+	// id is accessed atomically, so it is first to ensure 64-bit alignment.
+	id uint64
+
+	stored T
+	set    bool
+	mu     sync.Mutex
+	// notice is the pending notice that waiters are blocked on, if any.
+	notice *notice[T]
+	// waiters holds a notice for each waiter of a Value using WakeOne, in the
+	// order they started waiting.
+	waiters []*notice[T]
+	frozen  bool
+	version uint64
+	// versionChanged is closed when the version next changes, if it is not nil.
+	versionChanged chan struct{}
+	lastSet        time.Time
+	source         string
+	lastErr        error
+	// fallback is read through by Get and GetOk while the value is unset.
+	fallback Getter[T]
+	history  ring[T]
+	// changes holds the retained Events of the Value, and discardedVersion is
+	// the version of the newest one that is no longer retained.
+	changes          ring[Event[T]]
+	discardedVersion uint64
+	// cancelExpiry cancels the pending expiration from SetWithTTL, if any,
+	// which is due at expiresAt.
+	cancelExpiry func()
+	expiresAt    time.Time
+	opts         options[T]
+	// snapshot holds a *snapshot[T] of the stored value and set state, which
+	// is read by Peek without acquiring the lock.
+	snapshot atomic.Value
+
+	listeners    map[uint64]func()
+	lastListener uint64
+	// changed indicates that listeners must be notified when mu is released.
+	changed bool
+	// notifyQueue holds the listeners to call for changes, in order. They are
+	// called by the goroutine that set notifying.
+	notifyQueue []func()
+	notifying   bool
+
+	subscribers    map[uint64]subscriber
+	lastSubscriber uint64
+	// blocking is the number of Subscriptions using Block. While there are
+	// any, goroutines changing the value wait for delivered.
+	blocking  int
+	delivered []chan struct{}
+
+	handlers    []handler[T]
+	lastHandler uint64
+
+	// comparableEqual is the equality of a Comparable, which is used to skip
+	// setting equal values unless the Value was configured with WithEqual.
+	comparableEqual func(a, b T) bool
 }
 
-// initWaiting initializes the Explicit.waiting channel with a size of 0. This
-// makes it blocking even on the first write.
-//
-// initWaiting acquires the lock from start to finish.
-func (v *Value[T]) initWaiting() {
-	v.mu.Lock()
-	defer v.mu.Unlock()
+// snapshot is a copy of the stored value and set state of a Value.
+type snapshot[T any] struct {
+	stored T
+	set    bool
+}
+
+// publishLocked makes the stored value and set state available to Peek. The
+// lock must be held by the caller, unless the Value isn't shared yet.
+func (v *Value[T]) publishLocked() {
+	v.snapshot.Store(&snapshot[T]{stored: v.stored, set: v.set})
+}
+
+// notice is shared by every waiter blocked at the same time. It is delivered
+// by closing done, after which stored, set, and err hold what the waiters
+// return.
+type notice[T any] struct {
+	done   chan struct{}
+	stored T
+	set    bool
+	err    error
+}
+
+// noticeLocked returns the pending notice, creating it if there are no other
+// waiters, or if the Value uses WakeOne, in which case each waiter has its own.
+// The lock must be held by the caller.
+func (v *Value[T]) noticeLocked() *notice[T] {
+	if v.opts.wakePolicy == WakeOne {
+		pending := &notice[T]{done: make(chan struct{})}
+		v.waiters = append(v.waiters, pending)
+
+		return pending
+	}
+
+	if v.notice == nil {
+		v.notice = &notice[T]{done: make(chan struct{})}
+	}
 
-	if v.waiting == nil {
-		v.waiting = make(chan bool, 0)
+	return v.notice
+}
+
+// Set sets the value explicitly. Set panics if the value can't be set, such as
+// when the Value is frozen. Use SetErr to handle these cases as errors.
+func (v *Value[T]) Set(storeValue T) {
+	if err := v.SetErr(storeValue); err != nil {
+		panic(err)
 	}
 }
 
-// Set sets the value explicitly.
+// SetErr sets the value explicitly, or returns an error if it can't be set,
+// such as ErrFrozen when the Value is frozen, or the error returned by a
+// validator configured with WithValidator.
 //
-// Set acquires the lock prior to:
+// SetErr acquires the lock prior to:
 //
 // * setting the value
 //
-// * draining the waiting channel
-func (v *Value[T]) Set(storeValue T) {
-	v.initWaiting()
+// * waking waiters
+func (v *Value[T]) SetErr(storeValue T) error {
+	_, err := v.setErr(storeValue, "", nil)
+
+	return err
+}
+
+// TrySet sets the value explicitly, unless the lock is held by another
+// goroutine, and returns a boolean indicating if the value was set. It never
+// blocks, making it suitable for best-effort updates, such as telemetry, so it
+// also returns false while the Value has Subscriptions using Block, as setting
+// it would wait for delivery to them, and callbacks are called by another
+// goroutine. Unlike Set, TrySet returns false rather than panicking if the value
+// can't be set.
+func (v *Value[T]) TrySet(storeValue T) bool {
+	if !v.mu.TryLock() {
+		return false
+	}
+	defer v.unlockAsync()
+
+	if v.blocking > 0 {
+		return false
+	}
+
+	storeValue, err := v.prepareLocked(storeValue)
+	if err != nil {
+		return false
+	}
+
+	v.setLocked(storeValue)
+
+	return true
+}
+
+// SetContext sets the value explicitly, like SetErr, but gives up and returns
+// the Context's error if the lock can't be acquired before the Context is done.
+// It also stops waiting for callbacks to be called, and for the value to be
+// delivered to Subscriptions using Block, once the Context is done, in which
+// case the value is still set, and will still be delivered, but the Context's
+// error is returned.
+func (v *Value[T]) SetContext(ctx context.Context, storeValue T) error {
+	if err := v.lockContext(ctx); err != nil {
+		return err
+	}
+
+	storeValue, err := v.prepareLocked(storeValue)
+	if err != nil {
+		v.unlock()
+
+		return err
+	}
+
+	v.setLocked(storeValue)
+
+	return v.unlockContext(ctx)
+}
+
+// lockContext acquires the lock, unless the Context is done first, in which
+// case the Context's error is returned and the lock is not held.
+func (v *Value[T]) lockContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if v.mu.TryLock() {
+		return nil
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		v.mu.Lock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		return nil
+	case <-ctx.Done():
+		// the lock will still be acquired eventually, and must be released
+		go func() {
+			<-acquired
+			v.mu.Unlock()
+		}()
+
+		return ctx.Err()
+	}
+}
+
+// SetWithSource is like Set, but also records source as the origin of the value,
+// such as the name of the flag or environment variable it was read from.
+func (v *Value[T]) SetWithSource(storeValue T, source string) {
+	if _, err := v.setErr(storeValue, source, nil); err != nil {
+		panic(err)
+	}
+}
+
+// SetChanged is like Set, but returns a boolean indicating if the stored value
+// was changed. Only Values configured with WithEqual can detect that a value is
+// unchanged. For other Values, every set is a change.
+func (v *Value[T]) SetChanged(storeValue T) bool {
+	return v.SetChangedFunc(storeValue, nil)
+}
+
+// SetChangedFunc sets the value explicitly, unless it is already set to a value
+// that equal reports as equal to storeValue. It returns a boolean indicating if
+// the stored value was changed. Waiters are only woken if it was. If equal is
+// nil, the function configured with WithEqual is used, if any. Like Set,
+// SetChangedFunc panics if the value can't be set.
+func (v *Value[T]) SetChangedFunc(storeValue T, equal func(a, b T) bool) bool {
+	changed, err := v.setErr(storeValue, "", equal)
+	if err != nil {
+		panic(err)
+	}
+
+	return changed
+}
+
+// clock returns the Clock configured for the Value, or the system clock.
+func (v *Value[T]) clock() Clock {
+	if v.opts.clock == nil {
+		return systemClock{}
+	}
+
+	return v.opts.clock
+}
+
+// setErr implements SetErr, recording source as the origin of the value. If
+// the value is already set to a value that equal, or the function configured
+// with WithEqual or by Comparable, reports as equal to storeValue, waiters are
+// not woken. The returned boolean indicates if the stored value was changed.
+func (v *Value[T]) setErr(storeValue T, source string, equal func(a, b T) bool) (bool, error) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	storeValue, err := v.prepareLocked(storeValue)
+	if err != nil {
+		return false, err
+	}
+
+	if equal != nil && v.set && equal(v.stored, storeValue) {
+		return false, nil
+	}
+
+	return v.setSourceLocked(storeValue, source), nil
+}
+
+// equalLocked returns the function that determines if a new value is the same
+// as the stored value, configured with WithEqual or by Comparable, or nil if
+// every set is a change. The lock must be held by the caller.
+func (v *Value[T]) equalLocked() func(a, b T) bool {
+	if v.opts.equal != nil {
+		return v.opts.equal
+	}
+
+	return v.comparableEqual
+}
+
+// mutableLocked returns an error if the Value can't be changed. The lock must
+// be held by the caller.
+func (v *Value[T]) mutableLocked() error {
+	if v.frozen {
+		return fmt.Errorf("%s: %w", typeName[T](), ErrFrozen)
+	}
+
+	return nil
+}
 
-	// lock so nothing can add to waiting until after it's drained
+// mustMutableLocked panics with the error from mutableLocked, if any. It is
+// used by methods that change the value without returning an error. The lock
+// must be held by the caller.
+func (v *Value[T]) mustMutableLocked() {
+	if err := v.mutableLocked(); err != nil {
+		panic(err)
+	}
+}
+
+// prepareLocked returns storeValue as it should be stored, after normalization,
+// or an error if the Value can't be set to it, because it is frozen or
+// storeValue is invalid. The lock must be held by the caller.
+func (v *Value[T]) prepareLocked(storeValue T) (T, error) {
+	if err := v.mutableLocked(); err != nil {
+		return storeValue, err
+	}
+
+	storeValue = v.copied(storeValue)
+
+	for _, normalize := range v.opts.normalizers {
+		storeValue = normalize(storeValue)
+	}
+
+	for _, validate := range v.opts.validators {
+		if err := validate(storeValue); err != nil {
+			return storeValue, err
+		}
+	}
+
+	return storeValue, nil
+}
+
+// copied returns a copy of storeValue made by the function configured with
+// WithCopy, or storeValue itself if there is none.
+func (v *Value[T]) copied(storeValue T) T {
+	if v.opts.copy == nil {
+		return storeValue
+	}
+
+	return v.opts.copy(storeValue)
+}
+
+// mustPrepareLocked returns the result of prepareLocked, and panics with its
+// error, if any. It is used by methods that set the value without returning an
+// error. The lock must be held by the caller.
+func (v *Value[T]) mustPrepareLocked(storeValue T) T {
+	storeValue, err := v.prepareLocked(storeValue)
+	if err != nil {
+		panic(err)
+	}
+
+	return storeValue
+}
+
+// Freeze makes the Value immutable. After Freeze returns, methods that change
+// the value return ErrFrozen if they return an error, and panic otherwise.
+// Subscriptions end with ErrFrozen, after delivering the values already queued
+// for them. A pending expiration, from SetWithTTL or WithTTL, is cancelled, so
+// a frozen value never expires.
+func (v *Value[T]) Freeze() {
+	v.mu.Lock()
+	defer v.unlock()
+
+	v.frozen = true
+	v.cancelExpiryLocked()
+	v.endSubscriptionsLocked(ErrFrozen)
+}
+
+// SetFailed records err as the reason an attempt to set the value failed, and
+// wakes waiters, which will return err. The stored value is unchanged. This
+// permits waiters to distinguish a failed update from no update at all.
+//
+// SetFailed acquires the lock prior to recording err and waking waiters.
+func (v *Value[T]) SetFailed(err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.lastErr = err
+	v.drainLocked(err)
+}
+
+// LastError returns the error recorded by the most recent call to SetFailed,
+// or nil if the value has been explicitly set since.
+func (v *Value[T]) LastError() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.lastErr
+}
+
+// IsFrozen returns a boolean indicating if the Value has been frozen.
+func (v *Value[T]) IsFrozen() bool {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
+	return v.frozen
+}
+
+// recordLocked adds a copy of the stored value to the history, if it is set, so
+// the history doesn't share it with the value returned by the change that
+// replaced it. The lock must be held by the caller.
+func (v *Value[T]) recordLocked() {
+	if v.set {
+		v.history.push(v.copied(v.stored))
+	}
+}
+
+// setLocked sets the value explicitly and wakes waiters, and returns true,
+// unless the Value was configured with WithEqual, or is a Comparable, and is
+// already set to an equal value, in which case it does nothing and returns
+// false. The source is cleared, as the value no longer came from it. The lock
+// must be held by the caller.
+func (v *Value[T]) setLocked(storeValue T) bool {
+	return v.setSourceLocked(storeValue, "")
+}
+
+// setSourceLocked is like setLocked, but records source as the origin of the
+// value. The lock must be held by the caller.
+func (v *Value[T]) setSourceLocked(storeValue T, source string) bool {
+	if equal := v.equalLocked(); v.set && equal != nil && equal(v.stored, storeValue) {
+		return false
+	}
+
+	v.recordLocked()
+	v.cancelExpiryLocked()
+
+	old, oldSet := v.stored, v.set
 	v.stored = storeValue
 	v.set = true
+	v.bumpVersionLocked()
+	v.lastSet = v.clock().Now()
+	v.source = source
+	v.lastErr = nil
+	v.publishLocked()
+	v.emitLocked(Event[T]{
+		Kind:    EventSet,
+		Old:     old,
+		OldSet:  oldSet,
+		New:     v.stored,
+		Version: v.version,
+		Time:    v.lastSet,
+		Source:  source,
+	}, func() {
+		v.changedLocked()
+		v.drainLocked(nil)
+	})
+
+	if v.opts.ttl > 0 {
+		v.scheduleExpiryLocked(v.opts.ttl)
+	}
+
+	return true
+}
 
-	// drain waiting
-	for {
-		select {
-		default:
-			return
-		case <-v.waiting:
+// drainLocked delivers the pending notice, if any, waking every waiter, which
+// will return the stored value and waitErr. If the Value uses WakeOne, and
+// waitErr is nil, only the waiter that has waited longest is woken. The lock
+// must be held by the caller.
+func (v *Value[T]) drainLocked(waitErr error) {
+	if v.opts.wakePolicy == WakeOne {
+		woken := v.waiters
+		v.waiters = nil
+		if waitErr == nil && len(woken) > 1 {
+			woken, v.waiters = woken[:1], woken[1:]
 		}
+
+		for _, pending := range woken {
+			v.deliverLocked(pending, waitErr)
+		}
+
+		return
+	}
+
+	if v.notice == nil {
+		return
 	}
+
+	v.deliverLocked(v.notice, waitErr)
+	v.notice = nil
+}
+
+// deliverLocked delivers pending, waking its waiters, which will return the
+// stored value and waitErr. The lock must be held by the caller.
+func (v *Value[T]) deliverLocked(pending *notice[T], waitErr error) {
+	pending.stored = v.stored
+	pending.set = v.set
+	pending.err = waitErr
+	close(pending.done)
 }
 
-// Get returns the stored value.
+// abandon stops waiting for pending, if the Value uses WakeOne, so it doesn't
+// consume a set. It returns false if pending was already delivered, so the
+// waiter must return what it was delivered, as no other waiter was woken.
+func (v *Value[T]) abandon(pending *notice[T]) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.opts.wakePolicy != WakeOne {
+		return true
+	}
+
+	for i, waiting := range v.waiters {
+		if waiting == pending {
+			v.waiters = append(v.waiters[:i], v.waiters[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetIfUnset sets the value explicitly only if it is not already set, and
+// returns a boolean indicating if the value was set by this call.
+//
+// SetIfUnset holds the lock while checking and setting the value, so only one
+// of any number of concurrent callers will succeed.
+func (v *Value[T]) SetIfUnset(storeValue T) bool {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if v.set {
+		return false
+	}
+
+	v.setLocked(v.mustPrepareLocked(storeValue))
+
+	return true
+}
+
+// SetIf sets the value explicitly only if pred, called with the currently
+// stored value and a boolean indicating if it is explicitly set, returns true.
+// It returns a boolean indicating if the value was set by this call.
+//
+// SetIf holds the lock while calling pred, so pred must not call methods of
+// the same Value.
+func (v *Value[T]) SetIf(storeValue T, pred func(current T, set bool) bool) bool {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if !pred(v.stored, v.set) {
+		return false
+	}
+
+	v.setLocked(v.mustPrepareLocked(storeValue))
+
+	return true
+}
+
+// GetOrSet returns the stored value if it is explicitly set. Otherwise it sets
+// the value explicitly and returns it.
+//
+// GetOrSet holds the lock while checking and setting the value.
+func (v *Value[T]) GetOrSet(storeValue T) T {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if !v.set {
+		v.setLocked(v.mustPrepareLocked(storeValue))
+	}
+
+	return v.copied(v.stored)
+}
+
+// Swap sets the value explicitly, and returns the previously stored value and
+// a boolean indicating if it had been explicitly set.
+//
+// Swap holds the lock while reading the previous value and setting the new one.
+func (v *Value[T]) Swap(storeValue T) (T, bool) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	storeValue = v.mustPrepareLocked(storeValue)
+
+	old, wasSet := v.stored, v.set
+	v.setLocked(storeValue)
+
+	return old, wasSet
+}
+
+// Update sets the value explicitly to the result of calling fn with the
+// currently stored value. The stored value is the default value, if the Value
+// was configured with WithDefault, or the zero value of the type if it was
+// never set.
+//
+// Update holds the lock while calling fn, so fn must not call methods of the
+// same Value.
+func (v *Value[T]) Update(fn func(T) T) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	v.mustMutableLocked()
+	v.setLocked(v.mustPrepareLocked(fn(v.copied(v.stored))))
+}
+
+// UpdateErr is like Update, but fn may return an error. If it does, or the new
+// value can't be set, the update is aborted, leaving the stored value and its
+// set state unchanged, and the error is returned.
+func (v *Value[T]) UpdateErr(fn func(T) (T, error)) error {
+	v.mu.Lock()
+	defer v.unlock()
+
+	if err := v.mutableLocked(); err != nil {
+		return err
+	}
+
+	newValue, err := fn(v.copied(v.stored))
+	if err != nil {
+		return err
+	}
+
+	newValue, err = v.prepareLocked(newValue)
+	if err != nil {
+		return err
+	}
+
+	v.setLocked(newValue)
+
+	return nil
+}
+
+// Unset returns the value to its unset state, storing the default value, if the
+// Value was configured with WithDefault, or the zero value of the type. Waiters
+// are not woken, as the value has not been explicitly set.
+//
+// Unset acquires the lock prior to clearing the value, so it can't interleave
+// with a Set that is waking waiters.
+func (v *Value[T]) Unset() {
+	v.mu.Lock()
+	defer v.unlock()
+
+	v.mustMutableLocked()
+	v.unsetLocked(EventUnset)
+}
+
+// unsetLocked stores the default value and marks the value as unset, which is a
+// change of the given kind. The lock must be held by the caller.
+func (v *Value[T]) unsetLocked(kind EventKind) {
+	v.recordLocked()
+	v.cancelExpiryLocked()
+
+	old, oldSet := v.stored, v.set
+	v.stored = v.opts.defaultValue
+	v.set = false
+	v.bumpVersionLocked()
+	v.source = ""
+	v.publishLocked()
+
+	if !oldSet {
+		v.changedLocked()
+
+		return
+	}
+
+	v.emitLocked(Event[T]{
+		Kind:    kind,
+		Old:     old,
+		OldSet:  oldSet,
+		New:     v.stored,
+		Version: v.version,
+		Time:    v.clock().Now(),
+	}, v.changedLocked)
+}
+
+// changedLocked wakes goroutines waiting for the version to change, and marks
+// the listeners to be notified once the lock is released. The lock must be held
+// by the caller.
+func (v *Value[T]) changedLocked() {
+	v.wakeVersionLocked()
+	v.changed = true
+}
+
+// GetAndUnset returns the stored value and a boolean indicating if it was
+// explicitly set, and unsets it, as a single operation. This permits handing a
+// value off to a single consumer. Like Unset, waiters are not woken.
+//
+// GetAndUnset holds the lock while reading and unsetting the value.
+func (v *Value[T]) GetAndUnset() (T, bool) {
+	v.mu.Lock()
+	defer v.unlock()
+
+	stored, wasSet := v.stored, v.set
+	if wasSet {
+		v.mustMutableLocked()
+		v.unsetLocked(EventUnset)
+	}
+
+	return stored, wasSet
+}
+
+// Reset is like Unset, but also wakes waiters, which will return ErrReset.
+// This permits waiters to react to the value being revoked.
+//
+// Reset acquires the lock prior to clearing the value and waking waiters.
+func (v *Value[T]) Reset() {
+	v.mu.Lock()
+	defer v.unlock()
+
+	v.mustMutableLocked()
+	v.unsetLocked(EventUnset)
+	v.drainLocked(ErrReset)
+}
+
+// Get returns the stored value. If the value is unset and has a fallback, the
+// fallback's value is returned instead.
 func (v *Value[T]) Get() T {
-	return v.stored
+	v.mu.Lock()
+	if !v.set && v.fallback != nil {
+		fallback := v.fallback
+		v.mu.Unlock()
+
+		return fallback.Get()
+	}
+	defer v.mu.Unlock()
+
+	return v.copied(v.stored)
 }
 
 // GetOk returns the stored value and a boolean indicating if the value
-// was explicitly set.
+// was explicitly set. If the value is unset and has a fallback, the result of
+// the fallback's GetOk is returned instead.
 func (value *Value[T]) GetOk() (T, bool) {
-	return value.stored, value.set
+	value.mu.Lock()
+	if !value.set && value.fallback != nil {
+		fallback := value.fallback
+		value.mu.Unlock()
+
+		return fallback.GetOk()
+	}
+	defer value.mu.Unlock()
+
+	return value.copied(value.stored), value.set
+}
+
+// WithFallback configures v to read through to fallback while v is unset, and
+// returns v. Writes, and methods that report on v itself, such as IsSet,
+// Version and the waiting methods, are unaffected. Passing nil removes the
+// fallback.
+func (v *Value[T]) WithFallback(fallback Getter[T]) *Value[T] {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.fallback = fallback
+
+	return v
+}
+
+// GetOr returns the stored value if it is explicitly set, otherwise it returns
+// defaultValue.
+func (v *Value[T]) GetOr(defaultValue T) T {
+	if stored, ok := v.GetOk(); ok {
+		return stored
+	}
+
+	return defaultValue
+}
+
+// GetOrElse returns the stored value if it is explicitly set, otherwise it
+// returns the result of calling defaultFn. defaultFn is called without holding
+// the lock, and only if the value is not set.
+func (v *Value[T]) GetOrElse(defaultFn func() T) T {
+	if stored, ok := v.GetOk(); ok {
+		return stored
+	}
+
+	return defaultFn()
+}
+
+// GetErr returns the stored value, and ErrNotSet if it was not explicitly set.
+func (v *Value[T]) GetErr() (T, error) {
+	stored, ok := v.GetOk()
+	if !ok {
+		return stored, fmt.Errorf("%s: %w", typeName[T](), ErrNotSet)
+	}
+
+	return stored, nil
+}
+
+// MustGet returns the stored value, and panics with the error from GetErr if
+// it was not explicitly set.
+func (v *Value[T]) MustGet() T {
+	stored, err := v.GetErr()
+	if err != nil {
+		panic(err)
+	}
+
+	return stored
+}
+
+// Version returns the number of times the value has been changed by setting or
+// unsetting it. Sets that don't change the stored value, such as setting a
+// Comparable to the value it already holds, don't increment the version.
+func (v *Value[T]) Version() uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.version
+}
+
+// GetVersioned returns the stored value, its version, and a boolean indicating
+// if it was explicitly set, as of the same moment. This permits re-reading only
+// if the version has changed since a previous read.
+func (v *Value[T]) GetVersioned() (T, uint64, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.copied(v.stored), v.version, v.set
+}
+
+// LastSet returns the time the value was last explicitly set, and a boolean
+// indicating if it is currently set. If it isn't set, the zero time is returned.
+func (v *Value[T]) LastSet() (time.Time, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.set {
+		return time.Time{}, false
+	}
+
+	return v.lastSet, true
+}
+
+// Source returns the origin of the stored value, as recorded by SetWithSource.
+// It is empty if the value was set by other means, or is unset.
+func (v *Value[T]) Source() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.source
+}
+
+// Previous returns the most recently replaced or unset value, and a boolean
+// indicating if there is one. Previous values are only retained by Values
+// configured with WithHistory.
+func (v *Value[T]) Previous() (T, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	previous, ok := v.history.last()
+	if !ok {
+		return previous, false
+	}
+
+	return v.copied(previous), true
+}
+
+// History returns the retained previously stored values, oldest first. The
+// currently stored value is not included. Previous values are only retained by
+// Values configured with WithHistory.
+func (v *Value[T]) History() []T {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	history := v.history.slice()
+	for i, previous := range history {
+		history[i] = v.copied(previous)
+	}
+
+	return history
+}
+
+// Peek returns the stored value and a boolean indicating if it was explicitly
+// set, like GetOk, but without acquiring the lock. It is a relaxed read, which
+// may not observe changes that are still in progress, so it is only suitable
+// for reads that tolerate slightly stale values. Each change to the value
+// allocates a copy of it for Peek to read.
+func (v *Value[T]) Peek() (T, bool) {
+	current, ok := v.snapshot.Load().(*snapshot[T])
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	return v.copied(current.stored), current.set
+}
+
+// IsSet returns a boolean indicating if the value was explicitly set, without
+// copying the stored value.
+func (v *Value[T]) IsSet() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.set
+}
+
+// Equal reports whether v and other are both unset, or both explicitly set to
+// equal values. Stored values are compared with the function configured with
+// WithEqual, if any, or with ==, which panics if the values are not comparable,
+// such as slices and maps.
+//
+// Equal acquires each lock in turn, so it doesn't observe both Values at the
+// same moment if either is being changed.
+func (v *Value[T]) Equal(other *Value[T]) bool {
+	equal := v.opts.equal
+	if equal == nil {
+		equal = func(a, b T) bool {
+			return any(a) == any(b)
+		}
+	}
+
+	return v.equalFunc(other, equal)
+}
+
+// equalFunc implements Equal, comparing stored values with equal.
+func (v *Value[T]) equalFunc(other *Value[T], equal func(a, b T) bool) bool {
+	if v == other {
+		return true
+	}
+
+	stored, _, set := v.GetVersioned()
+	otherStored, _, otherSet := other.GetVersioned()
+
+	if set != otherSet {
+		return false
+	}
+
+	return !set || equal(stored, otherStored)
 }
 
 // GetWait returns the stored value, but blocks until the value is next
 // explicitly set, or the Context is cancelled. If returning after Context
-// cancellation, the last known stored value will be returned. This may be
-// the zero value of the type, if the value was never set.
+// cancellation, the last known stored value will be returned with a WaitError,
+// which matches ErrWaitCancelled or ErrWaitDeadline. This may be the default
+// or zero value of the type, if the value was never set. If the wait ended
+// because of Reset, ErrReset is returned, and if it ended because of
+// SetFailed, the error it was called with is returned.
+//
+// Every goroutine blocked in GetWait is woken by the same Set, and returns the
+// value it stored, even if the value has changed again since, unless the
+// Value was configured with WithWakePolicy to use WakeOne.
 func (v *Value[T]) GetWait(ctx context.Context) (T, error) {
-	v.initWaiting()
+	stored, _, err := v.GetWaitOk(ctx)
 
-	// acquiring the lock ensures the waiting channel isn't currently being drained.
-	// it is immediately unlocked again.
+	return stored, err
+}
+
+// GetWaitOk is like GetWait, but also returns a boolean indicating if the value
+// is explicitly set, so a stored value returned after Context cancellation, or
+// Reset, can be distinguished from one that was explicitly set.
+func (v *Value[T]) GetWaitOk(ctx context.Context) (T, bool, error) {
 	v.mu.Lock()
+	pending := v.noticeLocked()
 	v.mu.Unlock()
 
+	return v.awaitNotice(ctx, pending)
+}
+
+// awaitNotice blocks until pending is delivered, or the Context is cancelled,
+// and returns like GetWaitOk.
+func (v *Value[T]) awaitNotice(ctx context.Context, pending *notice[T]) (T, bool, error) {
 	select {
 	case <-ctx.Done():
-		return v.Get(), ctx.Err()
-	case v.waiting <- true:
-		return v.Get(), nil
+		if v.abandon(pending) {
+			stored, ok := v.GetOk()
+
+			return stored, ok, waitError(ctx.Err())
+		}
+	case <-pending.done:
 	}
-}
 
-// waitResponse can store the response from GetWait. It exists to be used for channel
-// types.
-type waitResponse[T any] struct {
-	stored T
-	err    error
+	return v.copied(pending.stored), pending.set, pending.err
 }
 
-// GetWaitTrigger backgrounds a call to GetWait, then runs the given trigger function.
+// GetWaitTrigger is like GetWait, but calls trigger once the caller is
+// registered as a waiter, so a set made by trigger, or made after trigger is
+// called, is never missed. trigger is called without holding the lock, so it
+// may set the Value.
 func (v *Value[T]) GetWaitTrigger(ctx context.Context, trigger func()) (T, error) {
-	waitChannel := make(chan waitResponse[T])
+	v.mu.Lock()
+	pending := v.noticeLocked()
+	v.mu.Unlock()
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		wg.Done()
+	trigger()
 
-		var waitingResponse waitResponse[T]
-		waitingResponse.stored, waitingResponse.err = v.GetWait(ctx)
-		waitChannel <- waitingResponse
-	}()
-	wg.Wait()
+	stored, _, err := v.awaitNotice(ctx, pending)
 
-	trigger()
+	return stored, err
+}
 
-	waitedGot := <-waitChannel
-	return waitedGot.stored, waitedGot.err
+// String returns a representation of the Value that reflects its set state,
+// such as "explicit(10)" or "unset(int)". It implements fmt.Stringer.
+func (v *Value[T]) String() string {
+	stored, ok := v.GetOk()
+	if !ok {
+		return fmt.Sprintf("unset(%s)", typeName[T]())
+	}
+
+	return fmt.Sprintf("explicit(%v)", stored)
 }
 
-// New returns a new Explicit with its value explicitly set.
-func New[T any](storeValue T) *Value[T] {
+// Clone returns a new Value with the same stored value and set state as v, but
+// with its own synchronization state. Changes to either Value are not observed
+// by the other. The returned Value has the same configuration as v, but is not
+// frozen, even if v is. If v is pending expiration, the returned Value expires
+// at the same time.
+func (v *Value[T]) Clone() *Value[T] {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	newValue := &Value[T]{
+		stored:          v.copied(v.stored),
+		set:             v.set,
+		lastSet:         v.lastSet,
+		source:          v.source,
+		fallback:        v.fallback,
+		history:         newRing[T](v.opts.historySize),
+		changes:         newRing[Event[T]](v.opts.historySize),
+		opts:            v.opts,
+		comparableEqual: v.comparableEqual,
+	}
+	newValue.publishLocked()
+
+	if v.cancelExpiry != nil {
+		newValue.scheduleExpiryLocked(v.expiresAt.Sub(v.reaper().clock.Now()))
+	}
+
+	return newValue
+}
+
+// New returns a new Explicit with its value explicitly set, configured by
+// opts.
+func New[T any](storeValue T, opts ...Option[T]) *Value[T] {
 	var newValue Value[T]
 
+	newValue.configure(opts)
 	newValue.Set(storeValue)
 
 	return &newValue
 }
+
+// typeName returns the name of type T, which is meaningful even when T is an
+// interface type.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "context"
+
+// ReadOnly is a handle to a Value that permits reading and waiting for it, but
+// not changing it. It can be handed to code that must not write back to the
+// Value. A ReadOnly must be created with Value.ReadOnly.
+type ReadOnly[T any] struct {
+	v *Value[T]
+}
+
+// ReadOnly returns a ReadOnly handle to v.
+func (v *Value[T]) ReadOnly() ReadOnly[T] {
+	return ReadOnly[T]{v: v}
+}
+
+// Get returns the stored value.
+func (r ReadOnly[T]) Get() T {
+	return r.v.Get()
+}
+
+// GetOk returns the stored value and a boolean indicating if the value was
+// explicitly set.
+func (r ReadOnly[T]) GetOk() (T, bool) {
+	return r.v.GetOk()
+}
+
+// GetOr returns the stored value if it is explicitly set, otherwise it returns
+// defaultValue.
+func (r ReadOnly[T]) GetOr(defaultValue T) T {
+	return r.v.GetOr(defaultValue)
+}
+
+// GetErr returns the stored value, and ErrNotSet if it was not explicitly set.
+func (r ReadOnly[T]) GetErr() (T, error) {
+	return r.v.GetErr()
+}
+
+// IsSet returns a boolean indicating if the value was explicitly set.
+func (r ReadOnly[T]) IsSet() bool {
+	return r.v.IsSet()
+}
+
+// Peek returns the stored value and a boolean indicating if it was explicitly
+// set, without acquiring the lock, like Value.Peek.
+func (r ReadOnly[T]) Peek() (T, bool) {
+	return r.v.Peek()
+}
+
+// Version returns the number of times the value has been changed.
+func (r ReadOnly[T]) Version() uint64 {
+	return r.v.Version()
+}
+
+// GetWait returns the stored value, but blocks until the value is next
+// explicitly set, or the Context is cancelled, like Value.GetWait.
+func (r ReadOnly[T]) GetWait(ctx context.Context) (T, error) {
+	return r.v.GetWait(ctx)
+}
+
+// String returns a representation of the Value that reflects its set state.
+func (r ReadOnly[T]) String() string {
+	return r.v.String()
+}
+
+// watch implements Watchable, so a ReadOnly can be a dependency of Derive.
+func (r ReadOnly[T]) watch(fn func()) (cancel func()) {
+	return r.v.watch(fn)
+}
+
+// watchSet implements Watchable.
+func (r ReadOnly[T]) watchSet(fn func()) (cancel func()) {
+	return r.v.watchSet(fn)
+}
+
+// watchEvents implements Watchable.
+func (r ReadOnly[T]) watchEvents(fn func(Event[any])) (cancel func()) {
+	return r.v.watchEvents(fn)
+}
+
+var (
+	_ Getter[any] = ReadOnly[any]{}
+	_ Watchable   = ReadOnly[any]{}
+)
+
+// Writer is a handle to a Value that permits setting and unsetting it, but not
+// reading it. It can be handed to producers, so that read-modify-write
+// sequences can only be performed with Value.Update. A Writer must be created
+// with Value.Writer.
+type Writer[T any] struct {
+	v *Value[T]
+}
+
+// Writer returns a Writer handle to v.
+func (v *Value[T]) Writer() Writer[T] {
+	return Writer[T]{v: v}
+}
+
+// Set sets the value explicitly, like Value.Set.
+func (w Writer[T]) Set(storeValue T) {
+	w.v.Set(storeValue)
+}
+
+// SetErr sets the value explicitly, or returns an error if it can't be set,
+// like Value.SetErr.
+func (w Writer[T]) SetErr(storeValue T) error {
+	return w.v.SetErr(storeValue)
+}
+
+// Unset returns the value to its unset state, like Value.Unset.
+func (w Writer[T]) Unset() {
+	w.v.Unset()
+}
+
+var _ Setter[any] = Writer[any]{}
@@ -0,0 +1,191 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"context"
+	"time"
+)
+
+// WaitSet returns the stored value immediately if it is explicitly set.
+// Otherwise it blocks like GetWait, until the value is explicitly set, or the
+// Context is cancelled. Unlike GetWait, which always waits for the next set,
+// this permits waiting until a value is available, such as configuration that
+// must be loaded before starting.
+func (v *Value[T]) WaitSet(ctx context.Context) (T, error) {
+	v.mu.Lock()
+	if v.set {
+		defer v.mu.Unlock()
+
+		return v.copied(v.stored), nil
+	}
+	pending := v.noticeLocked()
+	v.mu.Unlock()
+
+	stored, _, err := v.awaitNotice(ctx, pending)
+
+	return stored, err
+}
+
+// WaitOr is like WaitSet, but returns fallback if the wait ends before the
+// value is explicitly set, such as because the Context is cancelled, rather
+// than the stored value, which may be the zero value of the type. This permits
+// degrading gracefully when a value isn't available in time.
+func (v *Value[T]) WaitOr(ctx context.Context, fallback T) T {
+	stored, err := v.WaitSet(ctx)
+	if err != nil {
+		return fallback
+	}
+
+	return stored
+}
+
+// WaitTimeout is like GetWait, but waits at most d, after which the last known
+// stored value is returned with an error matching ErrWaitDeadline.
+func (v *Value[T]) WaitTimeout(d time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	return v.GetWait(ctx)
+}
+
+// WaitFor returns the stored value once it satisfies pred, which is called with
+// the current value, if it is explicitly set, and then with every value it is
+// subsequently set to, so none are missed. If the Context is cancelled first,
+// the last known stored value is returned with a WaitError, like GetWait. If
+// the Value is frozen before pred is satisfied, ErrFrozen is returned, as it
+// never will be. pred is called without holding the lock.
+func (v *Value[T]) WaitFor(ctx context.Context, pred func(T) bool) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub := v.NewSubscription(ctx)
+
+	if stored, _, set := v.GetVersioned(); set && pred(stored) {
+		return stored, nil
+	}
+
+	for storeValue := range sub.C() {
+		if pred(storeValue) {
+			return storeValue, nil
+		}
+	}
+
+	return v.Get(), waitError(sub.Err())
+}
+
+// bumpVersionLocked increments the version. Goroutines waiting for it to
+// change are woken by wakeVersionLocked, once the change is delivered. The lock
+// must be held by the caller.
+func (v *Value[T]) bumpVersionLocked() {
+	v.version++
+}
+
+// wakeVersionLocked wakes goroutines waiting for the version to change. The
+// lock must be held by the caller.
+func (v *Value[T]) wakeVersionLocked() {
+	if v.versionChanged != nil {
+		close(v.versionChanged)
+		v.versionChanged = nil
+	}
+}
+
+// versionChangedLocked returns a channel that is closed when the version next
+// changes. The lock must be held by the caller.
+func (v *Value[T]) versionChangedLocked() <-chan struct{} {
+	if v.versionChanged == nil {
+		v.versionChanged = make(chan struct{})
+	}
+
+	return v.versionChanged
+}
+
+// WaitChangedSince returns the stored value and its version once the version
+// differs from version, returning immediately if it already does. Passing the
+// version of the last value a consumer processed, such as from GetVersioned,
+// ensures it isn't woken for a change it has already seen, and learns of any
+// made since, even ones made while it wasn't waiting. If the Context is
+// cancelled first, the current value and version are returned with a
+// WaitError, like GetWait.
+func (v *Value[T]) WaitChangedSince(ctx context.Context, version uint64) (T, uint64, error) {
+	for {
+		v.mu.Lock()
+		if v.version != version {
+			defer v.mu.Unlock()
+
+			return v.copied(v.stored), v.version, nil
+		}
+		changed := v.versionChangedLocked()
+		v.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			stored, current, _ := v.GetVersioned()
+
+			return stored, current, waitError(ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Changed returns a channel that is closed when the value next changes, by
+// setting or unsetting it, like its version. Waiters blocked on it are woken
+// without being passed the value, which they may read themselves, such as with
+// Get, so a large value isn't copied for each of them. Every caller waiting for
+// the same change shares the channel. Calling Changed before reading the value
+// ensures a change made after reading it isn't missed.
+func (v *Value[T]) Changed() <-chan struct{} {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.versionChangedLocked()
+}
+
+// WaitAny blocks until any of vs is next explicitly set, or the Context is
+// cancelled, and returns the index of the first one set. If the Context is
+// cancelled first, -1 is returned with a WaitError, like GetWait.
+func WaitAny(ctx context.Context, vs ...Watchable) (int, error) {
+	// buffered so that setting any of vs never blocks
+	setIndex := make(chan int, len(vs))
+
+	for i, v := range vs {
+		i := i
+		cancel := v.watchSet(func() {
+			select {
+			case setIndex <- i:
+			default:
+			}
+		})
+		defer cancel()
+	}
+
+	select {
+	case <-ctx.Done():
+		return -1, waitError(ctx.Err())
+	case i := <-setIndex:
+		return i, nil
+	}
+}
+
+// WaitAll blocks until each of vs has been explicitly set, either before WaitAll
+// was called, if it is still set, or while waiting, or the Context is
+// cancelled, in which case a WaitError is returned, like GetWait. Values that
+// are unset again after being set still count as set.
+func WaitAll(ctx context.Context, vs ...Watchable) error {
+	barrier := NewBarrier(0, vs...)
+	defer barrier.Stop()
+
+	return barrier.Wait(ctx)
+}
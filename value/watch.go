@@ -0,0 +1,190 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import "context"
+
+// Watchable is implemented by Values, and the types built on them. It permits
+// being notified when they change, such as by Derive.
+type Watchable interface {
+	// watch registers fn to be called after each change, and returns a
+	// function that removes it.
+	watch(fn func()) (cancel func())
+
+	// watchSet registers fn to be called after each explicit set, and returns a
+	// function that removes it.
+	watchSet(fn func()) (cancel func())
+
+	// IsSet returns a boolean indicating if the value is explicitly set.
+	IsSet() bool
+
+	// watchEvents registers fn to be called with an Event for each change, and
+	// returns a function that removes it.
+	watchEvents(fn func(Event[any])) (cancel func())
+}
+
+var _ Watchable = (*Value[any])(nil)
+
+// watch registers fn to be called after each change of the Value, and returns
+// a function that removes it. fn is called without holding the lock, one
+// listener at a time, in the order of the changes. It may be called by any
+// goroutine that changes the Value, not necessarily the one that made the
+// change it is called for.
+func (v *Value[T]) watch(fn func()) (cancel func()) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.listeners == nil {
+		v.listeners = map[uint64]func(){}
+	}
+
+	v.lastListener++
+	id := v.lastListener
+	v.listeners[id] = fn
+
+	return func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		delete(v.listeners, id)
+	}
+}
+
+// watchSet registers fn to be called after each explicit set of the Value, and
+// returns a function that removes it. fn is called like the callbacks
+// registered by OnSet.
+func (v *Value[T]) watchSet(fn func()) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		if e.Kind == EventSet {
+			fn()
+		}
+	}, nil)
+}
+
+// watchEvents registers fn to be called with an Event for each change of the
+// Value, and returns a function that removes it. fn is called like the
+// callbacks registered by OnEvent.
+func (v *Value[T]) watchEvents(fn func(Event[any])) (cancel func()) {
+	return v.handle(func(e Event[T]) {
+		fn(e.Any())
+	}, nil)
+}
+
+// unlock releases the lock, then notifies listeners of any changes, and waits
+// for them to be delivered to Subscriptions that use Block.
+func (v *Value[T]) unlock() {
+	delivered := v.delivered
+	v.delivered = nil
+
+	if v.unlockQueued() {
+		v.notifyListeners()
+	}
+
+	waitDelivered(delivered)
+}
+
+// unlockContext is like unlock, but stops waiting for listeners to be notified,
+// and for delivery to Subscriptions that use Block, once ctx is done, returning
+// its error. Listeners are notified by another goroutine, as they may block.
+func (v *Value[T]) unlockContext(ctx context.Context) error {
+	delivered := v.delivered
+	v.delivered = nil
+
+	if v.unlockQueued() {
+		notified := make(chan struct{})
+		delivered = append(delivered, notified)
+
+		go func() {
+			defer close(notified)
+
+			v.notifyListeners()
+		}()
+	}
+
+	for _, done := range delivered {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+		}
+	}
+
+	return nil
+}
+
+// unlockAsync is like unlock, but notifies listeners in another goroutine, so
+// the caller doesn't block on them. It doesn't wait for delivery to
+// Subscriptions that use Block.
+func (v *Value[T]) unlockAsync() {
+	if v.unlockQueued() {
+		go v.notifyListeners()
+	}
+}
+
+// unlockQueued queues the listeners to call for any changes, then releases the
+// lock. It returns a boolean indicating if the caller is responsible for calling
+// notifyListeners, because no other goroutine is already notifying them.
+func (v *Value[T]) unlockQueued() bool {
+	if v.changed {
+		v.changed = false
+
+		for _, fn := range v.listeners {
+			v.notifyQueue = append(v.notifyQueue, fn)
+		}
+	}
+
+	notify := len(v.notifyQueue) > 0 && !v.notifying
+	if notify {
+		v.notifying = true
+	}
+
+	v.mu.Unlock()
+
+	return notify
+}
+
+// notifyListeners calls queued listeners without holding the lock, until the
+// queue is empty. Only one goroutine notifies the listeners of a Value at a
+// time, so they observe changes in the order they were made, and changes made
+// by listeners themselves are queued rather than deadlocking.
+func (v *Value[T]) notifyListeners() {
+	finished := false
+	defer func() {
+		// a panicking listener must not prevent future notifications
+		if !finished {
+			v.mu.Lock()
+			v.notifying = false
+			v.mu.Unlock()
+		}
+	}()
+
+	for {
+		v.mu.Lock()
+		queue := v.notifyQueue
+		v.notifyQueue = nil
+		if len(queue) == 0 {
+			v.notifying = false
+			finished = true
+			v.mu.Unlock()
+
+			return
+		}
+		v.mu.Unlock()
+
+		for _, fn := range queue {
+			fn()
+		}
+	}
+}
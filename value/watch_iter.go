@@ -0,0 +1,43 @@
+// Copyright 2022 Micah Kemp
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package value
+
+import (
+	"context"
+	"iter"
+)
+
+// Watch returns an iterator over every value v is set to after iteration
+// starts, which stops when ctx is done or v is frozen. Iteration is backed by a
+// Subscription, which is closed when the loop exits. This permits ranging over
+// updates:
+//
+//	for timeout := range cfg.Timeout.Watch(ctx) {
+//		...
+//	}
+func (v *Value[T]) Watch(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sub := v.NewSubscription(ctx)
+		defer sub.Close()
+
+		for storeValue := range sub.C() {
+			if !yield(storeValue) {
+				return
+			}
+		}
+	}
+}